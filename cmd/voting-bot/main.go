@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -19,7 +20,11 @@ func main() {
 	logger.InitLogger()
 	slog.Info("Starting Mattermost voting bot...")
 
-	cfg := config.NewConfig()
+	cfg, err := config.NewConfig()
+	if err != nil {
+		slog.Error("Invalid config", "error", err)
+		os.Exit(1)
+	}
 	slog.Info("Config loaded",
 		"tarantool_addr", cfg.TarantoolAddr,
 		"mattermost_url", cfg.MattermostBotURL,
@@ -37,7 +42,6 @@ func main() {
 	}
 
 	var tarantoolStore *db.TarantoolStorage
-	var err error
 
 	for attempts := 1; attempts <= 3; attempts++ {
 		slog.Info("Connection attempt", "attempt", attempts)
@@ -74,7 +78,7 @@ func main() {
 
 	slog.Info("Connected to Tarantool successfully")
 
-	botService := service.NewService(tarantoolStore, nil)
+	botService := service.NewService(tarantoolStore, nil, cfg.PollConfig)
 
 	slog.Info("Connecting to Mattermost...")
 
@@ -85,6 +89,7 @@ func main() {
 	}
 
 	botService.SetNotifier(mmClient)
+	botService.SetDirectory(mmClient)
 
 	defer mmClient.Close()
 
@@ -97,6 +102,12 @@ func main() {
 
 	mmClient.StartListening()
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go botService.RunScheduler(ctx, cfg.SchedulerInterval)
+	go botService.RunRetention(ctx, cfg.RetentionInterval)
+
 	slog.Info("Bot is now running. Press CTRL+C to exit.")
 
 	sig := make(chan os.Signal, 1)
@@ -5,18 +5,32 @@ box.cfg{
 box.schema.user.create('storage', {password = 'password', if_not_exists = true})
 box.schema.user.grant('storage', 'super', nil, nil, {if_not_exists = true})
 
+local polls_format = {
+    {name = 'id', type = 'string'},
+    {name = 'title', type = 'string'},
+    {name = 'options', type = 'array'},
+    {name = 'created_by', type = 'string'},
+    {name = 'created_at', type = 'unsigned'},
+    {name = 'status', type = 'string'}, -- draft, scheduled, open, closed or trashed
+    {name = 'votes', type = 'map'}, -- map[user_id] = ballot
+    {name = 'settings', type = 'map', is_nullable = true},
+    {name = 'suggestions', type = 'array', is_nullable = true}, -- write-in options awaiting approval
+    {name = 'tie_break', type = 'map', is_nullable = true}, -- how a tie for the win was resolved
+    {name = 'channel_id', type = 'string'},
+    {name = 'closes_at', type = 'unsigned'}, -- 0 means the poll never closes automatically
+    {name = 'opens_at', type = 'unsigned'},
+    {name = 'reminded', type = 'array', is_nullable = true}, -- reminder offsets already sent
+    {name = 'series_id', type = 'string', is_nullable = true}, -- recurring series the poll belongs to
+    {name = 'cloned_from', type = 'string', is_nullable = true}, -- poll this poll was cloned from
+    {name = 'events', type = 'array', is_nullable = true}, -- close, reopen and extend history
+    {name = 'edits', type = 'array', is_nullable = true}, -- title and option changes
+    {name = 'deleted_at', type = 'unsigned', is_nullable = true}, -- time the poll was moved to the trash
+    {name = 'deleted_from', type = 'string', is_nullable = true}, -- status before the poll was moved to the trash
+    {name = 'owners', type = 'array', is_nullable = true} -- users who manage the poll, empty means the creator
+}
+
 if not box.space.polls then
-    local polls = box.schema.space.create('polls', {
-        format = {
-            {name = 'id', type = 'string'},
-            {name = 'title', type = 'string'},
-            {name = 'options', type = 'array'},
-            {name = 'created_by', type = 'string'},
-            {name = 'created_at', type = 'unsigned'},
-            {name = 'is_active', type = 'boolean', default = true},
-            {name = 'votes', type = 'map'} -- map[user_id] = option_index
-        }
-    })
+    local polls = box.schema.space.create('polls', {format = polls_format})
 
     polls:create_index('primary', {
         if_not_exists = true,
@@ -24,24 +38,10 @@ if not box.space.polls then
         parts = {'id'}
     })
 
-    polls:create_index('created_by', {
-        if_not_exists = true,
-        type = 'TREE',
-        parts = {'created_by'},
-        unique = false
-    })
-
-    polls:create_index('is_active', {
-        if_not_exists = true,
-        type = 'TREE',
-        parts = {'is_active'},
-        unique = false 
-    })
-
-    local votes1 = {}
-    votes1.Red = 0
-    votes1.Green = 0
-    votes1.Blue = 0
+    local votes1 = {
+        user_b = {choices = {'Red'}, weight = 1, cast_at = 0, reason = ''},
+        user_c = {choices = {'Blue'}, weight = 1, cast_at = 0, reason = ''}
+    }
 
     polls:insert({
         'poll1',
@@ -49,9 +49,173 @@ if not box.space.polls then
         {'Red', 'Green', 'Blue'},
         'user_a',
         1682514732,
-        true,
-        votes1
+        'open',
+        votes1,
+        box.NULL,
+        {},
+        box.NULL,
+        '',
+        0,
+        1682514732,
+        {},
+        box.NULL,
+        box.NULL,
+        {},
+        {},
+        box.NULL,
+        box.NULL,
+        box.NULL
     })
+elseif box.space.polls:format()[6].name == 'is_active' then
+    -- polls created before the lifecycle states store a boolean is_active flag
+    -- and may lack the trailing fields
+    local polls = box.space.polls
+    for _, name in ipairs({'is_active', 'closes_at'}) do
+        if polls.index[name] ~= nil then
+            polls.index[name]:drop()
+        end
+    end
+    polls:format({})
+
+    for _, tuple in ipairs(polls:select()) do
+        local t = tuple:totable()
+        t[6] = t[6] and 'open' or 'closed'
+        for i = #t + 1, 10 do
+            t[i] = box.NULL
+        end
+        t[11] = t[11] or ''
+        t[12] = t[12] or 0
+        t[13] = t[13] or t[5]
+        polls:replace(t)
+    end
+
+    polls:format(polls_format)
+elseif #box.space.polls:format() < #polls_format then
+    -- trailing fields added later are nullable, existing tuples stay valid
+    box.space.polls:format(polls_format)
 end
 
-require('msgpack').cfg{encode_invalid_as_nil = true}
\ No newline at end of file
+local polls = box.space.polls
+
+polls:create_index('created_by', {
+    if_not_exists = true,
+    type = 'TREE',
+    parts = {'created_by'},
+    unique = false
+})
+
+polls:create_index('status', {
+    if_not_exists = true,
+    type = 'TREE',
+    parts = {'status'},
+    unique = false
+})
+
+-- polls by state and closing time, open polls are scanned by the auto-close scheduler
+polls:create_index('closes_at', {
+    if_not_exists = true,
+    type = 'TREE',
+    parts = {'status', 'closes_at'},
+    unique = false
+})
+
+-- polls by state and opening time, scheduled polls are scanned by the scheduler
+polls:create_index('opens_at', {
+    if_not_exists = true,
+    type = 'TREE',
+    parts = {'status', 'opens_at'},
+    unique = false
+})
+
+-- recurring poll definitions, each run opens a new poll in the series' channel
+local series = box.schema.space.create('series', {
+    if_not_exists = true,
+    format = {
+        {name = 'id', type = 'string'},
+        {name = 'title', type = 'string'},
+        {name = 'options', type = 'array'},
+        {name = 'settings', type = 'map'},
+        {name = 'created_by', type = 'string'},
+        {name = 'created_at', type = 'unsigned'},
+        {name = 'channel_id', type = 'string'},
+        {name = 'schedule', type = 'string'}, -- cron schedule in UTC
+        {name = 'next_run_at', type = 'unsigned'}, -- 0 means the series is stopped
+        {name = 'poll_ids', type = 'array'}
+    }
+})
+
+series:create_index('primary', {
+    if_not_exists = true,
+    type = 'HASH',
+    parts = {'id'}
+})
+
+series:create_index('next_run_at', {
+    if_not_exists = true,
+    type = 'TREE',
+    parts = {'next_run_at'},
+    unique = false
+})
+
+-- poll templates of each team, private templates are owned by their author
+local templates = box.schema.space.create('templates', {
+    if_not_exists = true,
+    format = {
+        {name = 'team_id', type = 'string'},
+        {name = 'owner', type = 'string'}, -- empty for templates shared with the team
+        {name = 'name', type = 'string'},
+        {name = 'title', type = 'string'},
+        {name = 'options', type = 'array'},
+        {name = 'settings', type = 'map'},
+        {name = 'created_by', type = 'string'},
+        {name = 'created_at', type = 'unsigned'}
+    }
+})
+
+templates:create_index('primary', {
+    if_not_exists = true,
+    type = 'TREE',
+    parts = {'team_id', 'owner', 'name'}
+})
+
+-- users who opted out of reminder DMs
+local optouts = box.schema.space.create('reminder_optouts', {
+    if_not_exists = true,
+    format = {
+        {name = 'user_id', type = 'string'},
+        {name = 'opted_out_at', type = 'unsigned'}
+    }
+})
+
+optouts:create_index('primary', {
+    if_not_exists = true,
+    type = 'HASH',
+    parts = {'user_id'}
+})
+
+-- closed polls moved out of the polls space by the retention policy, the poll tuple is kept as is
+local archive = box.schema.space.create('polls_archive', {
+    if_not_exists = true,
+    format = {
+        {name = 'id', type = 'string'},
+        {name = 'closed_at', type = 'unsigned'},
+        {name = 'archived_at', type = 'unsigned'},
+        {name = 'poll', type = 'array'} -- tuple in the polls space format
+    }
+})
+
+archive:create_index('primary', {
+    if_not_exists = true,
+    type = 'HASH',
+    parts = {'id'}
+})
+
+-- archived polls by closing time, scanned when the retention period ends
+archive:create_index('closed_at', {
+    if_not_exists = true,
+    type = 'TREE',
+    parts = {'closed_at'},
+    unique = false
+})
+
+require('msgpack').cfg{encode_invalid_as_nil = true}
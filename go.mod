@@ -7,6 +7,7 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/mattermost/mattermost-server/v6 v6.7.2
 	github.com/tarantool/go-tarantool v1.12.2
+	gopkg.in/vmihailenco/msgpack.v2 v2.9.2
 )
 
 require (
@@ -53,6 +54,5 @@ require (
 	google.golang.org/protobuf v1.28.0 // indirect
 	gopkg.in/ini.v1 v1.66.4 // indirect
 	gopkg.in/natefinch/lumberjack.v2 v2.0.0 // indirect
-	gopkg.in/vmihailenco/msgpack.v2 v2.9.2 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
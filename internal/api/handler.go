@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -16,6 +17,21 @@ type PollCommandHandler interface {
 	HandleCommand(command string, args []string, userID, channelID string) (string, error)
 }
 
+// ephemeralCommands lists commands whose responses are shown only to the user who ran them
+var ephemeralCommands = map[string]bool{
+	"poll-vote":      true,
+	"poll-unvote":    true,
+	"poll-reminders": true,
+	"poll-trash":     true,
+	"poll-admin":     true,
+}
+
+// ballotCommands lists commands whose arguments after the poll ID contain the user's choices.
+// Such arguments are never logged
+var ballotCommands = map[string]bool{
+	"poll-vote": true,
+}
+
 // CommandRequest  represents a request to execute a command
 type CommandRequest struct {
 	Command     string   `json:"command" form:"command"`
@@ -111,18 +127,22 @@ func (h *HTTPHandler) handleCommand(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		for key, values := range r.Form {
-			slog.Debug("Form parameter", "key", key, "values", values)
-		}
-
 		commandName = r.Form.Get("command")
 		text := r.Form.Get("text")
 		userID = r.Form.Get("user_id")
 		channelID = r.Form.Get("channel_id")
 
+		redactText := ballotCommands[strings.TrimPrefix(commandName, "/")]
+		for key, values := range r.Form {
+			if key == "token" || (key == "text" && redactText) {
+				continue
+			}
+			slog.Debug("Form parameter", "key", key, "values", values)
+		}
+
 		if text != "" {
 			args = parseCommandArgs(text)
-			slog.Debug("Parsed arguments", "count", len(args), "args", args)
+			slog.Debug("Parsed arguments", "count", len(args))
 		}
 	}
 
@@ -130,25 +150,45 @@ func (h *HTTPHandler) handleCommand(w http.ResponseWriter, r *http.Request) {
 
 	slog.Info("Processing command",
 		"command", commandName,
-		"args", args,
+		"args", redactArgs(commandName, args),
 		"user_id", userID,
 		"channel_id", channelID)
 
 	response, err := h.commandHandler.HandleCommand(commandName, args, userID, channelID)
 	if err != nil {
-		slog.Error("Failed to handle command", "error", err)
+		// errors that know how to log themselves, like ballot errors of anonymous polls,
+		// are logged that way even when wrapped
+		var logged any = err
+		var valuer slog.LogValuer
+		if errors.As(err, &valuer) {
+			logged = valuer
+		}
+		slog.Error("Failed to handle command", "error", logged)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	responseType := "in_channel"
+	if ephemeralCommands[commandName] {
+		responseType = "ephemeral"
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(CommandResponse{
-		ResponseType: "in_channel",
+		ResponseType: responseType,
 		Text:         response,
 	})
 }
 
+// redactArgs hides the user's choices in the arguments of ballot commands
+func redactArgs(command string, args []string) []string {
+	if !ballotCommands[command] || len(args) <= 1 {
+		return args
+	}
+	return []string{args[0], "[redacted]"}
+}
+
 // parseCommandArgs parses command arguments enclosed in double quotes
 func parseCommandArgs(text string) []string {
 	if text == "" {
@@ -1,8 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -11,6 +14,7 @@ import (
 type Config struct {
 	MattermostConfig
 	TarantoolConfig
+	PollConfig
 }
 
 // Config contains Mattermost config
@@ -28,13 +32,40 @@ type TarantoolConfig struct {
 	TarantoolPass string
 }
 
-// NewConfig creates a new config
-func NewConfig() *Config {
+// PollConfig contains voting config
+type PollConfig struct {
+	VoteKeySecret      string
+	SchedulerInterval  time.Duration // how often overdue polls are closed
+	TrashRetentionDays int           // how long deleted polls can be restored before they are purged
+	ArchiveAfterDays   int           // how long after closing polls are moved to the archive
+	PurgeAfterDays     int           // how long after closing archived polls are purged
+	RetentionInterval  time.Duration // how often the retention policy is enforced
+}
+
+// MaxRetentionDays is the longest retention period, ten years
+const MaxRetentionDays = 3650
+
+// NewConfig creates a new config. Invalid retention periods are an error,
+// a mistake there would archive or purge polls too early
+func NewConfig() (*Config, error) {
 	err := godotenv.Load()
 	if err != nil && !os.IsNotExist(err) {
 		log.Println("Error loading .env file:", err)
 	}
 
+	archiveAfterDays, err := getDays("ARCHIVE_AFTER_DAYS", 90)
+	if err != nil {
+		return nil, err
+	}
+	purgeAfterDays, err := getDays("PURGE_AFTER_DAYS", 365)
+	if err != nil {
+		return nil, err
+	}
+	if purgeAfterDays < archiveAfterDays {
+		return nil, fmt.Errorf("PURGE_AFTER_DAYS (%d) must not be less than ARCHIVE_AFTER_DAYS (%d)",
+			purgeAfterDays, archiveAfterDays)
+	}
+
 	return &Config{
 		MattermostConfig: MattermostConfig{
 			MattermostBotHTTPAddr: getEnv("MATTERMOST_BOT_HTTP_ADDR", "http://localhost:8080"),
@@ -47,7 +78,15 @@ func NewConfig() *Config {
 			TarantoolUser: getEnv("TARANTOOL_USER", "storage"),
 			TarantoolPass: getEnv("TARANTOOL_PASS", "password"),
 		},
-	}
+		PollConfig: PollConfig{
+			VoteKeySecret:      getEnv("VOTE_KEY_SECRET", ""),
+			SchedulerInterval:  getDuration("POLL_SCHEDULER_INTERVAL", 30*time.Second),
+			TrashRetentionDays: getInt("TRASH_RETENTION_DAYS", 30),
+			ArchiveAfterDays:   archiveAfterDays,
+			PurgeAfterDays:     purgeAfterDays,
+			RetentionInterval:  getDuration("RETENTION_INTERVAL", time.Hour),
+		},
+	}, nil
 }
 
 // getEnv is a helper function for receiving env variables with default value
@@ -58,3 +97,47 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// getInt is a helper function for receiving positive integer env variables with default value
+func getInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Invalid %s %q, using %d\n", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+// getDays is a helper function for receiving retention periods in days with default value
+func getDays(key string, defaultValue int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 || n > MaxRetentionDays {
+		return 0, fmt.Errorf("invalid %s %q: must be a number of days from 1 to %d", key, value, MaxRetentionDays)
+	}
+	return n, nil
+}
+
+// getDuration is a helper function for receiving duration env variables with default value
+func getDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using %s\n", key, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
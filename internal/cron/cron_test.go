@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"@yearly",
+	}
+
+	for _, spec := range tests {
+		t.Run(spec, func(t *testing.T) {
+			if _, err := Parse(spec); err == nil {
+				t.Errorf("Parse(%q) succeeded, want an error", spec)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	// a Wednesday
+	from := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"weekday name", "0 12 * * fri", from, time.Date(2025, 1, 17, 12, 0, 0, 0, time.UTC)},
+		{"hourly", "@hourly", from, time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)},
+		{"daily", "@daily", from, time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"weekly on monday", "@weekly", from, time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)},
+		{"monthly", "@monthly", from, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"shorthand is case-insensitive", " @Daily ", from, time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC)},
+		{"the current minute is skipped", "30 10 * * *", from, time.Date(2025, 1, 16, 10, 30, 0, 0, time.UTC)},
+		{"seconds are ignored", "*/15 * * * *", from.Add(20 * time.Second), time.Date(2025, 1, 15, 10, 45, 0, 0, time.UTC)},
+		{"list", "15,45 * * * *", from, time.Date(2025, 1, 15, 10, 45, 0, 0, time.UTC)},
+		{"range with step", "0 8-10/2 * * *", from, time.Date(2025, 1, 16, 8, 0, 0, 0, time.UTC)},
+		{"value with step", "0 20/2 * * *", from, time.Date(2025, 1, 15, 20, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", from, time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"sunday as 0", "0 0 * * sun", from, time.Date(2025, 1, 19, 0, 0, 0, 0, time.UTC)},
+		{"month name", "0 0 1 dec *", from, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{"either day field matches", "0 9 1 * mon", from, time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", from, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"never matches", "0 0 30 2 *", from, time.Time{}},
+		{"other time zones are converted to UTC", "@hourly", from.In(time.FixedZone("UTC+2", 2*60*60)),
+			time.Date(2025, 1, 15, 11, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.spec)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.spec, err)
+			}
+			if got := schedule.Next(tt.from); !got.Equal(tt.want) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got, tt.want)
+			}
+		})
+	}
+}
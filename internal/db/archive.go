@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+	"github.com/tarantool/go-tarantool"
+	pool "github.com/tarantool/go-tarantool/connection_pool"
+)
+
+// ArchivePoll moves a closed poll to the polls_archive space. The poll is copied before it is
+// removed from the polls space, so an interrupted move is completed by the next run
+func (s *TarantoolStorage) ArchivePoll(ctx context.Context, archived *model.ArchivedPoll) error {
+	slog.Info("Archiving poll in Tarantool", "poll_id", archived.Poll.ID)
+
+	_, err := s.connPool.Replace(
+		"polls_archive",
+		[]interface{}{
+			archived.Poll.ID,
+			archived.ClosedAt,
+			archived.ArchivedAt,
+			pollToTuple(archived.Poll),
+		},
+		pool.RW,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to archive poll: %w", err)
+	}
+
+	if _, err := s.connPool.Delete("polls", "primary", []interface{}{archived.Poll.ID}, pool.RW); err != nil {
+		return fmt.Errorf("failed to delete archived poll: %w", err)
+	}
+
+	return nil
+}
+
+// GetArchivedPoll retrieves an archived poll
+func (s *TarantoolStorage) GetArchivedPoll(ctx context.Context, id string) (*model.ArchivedPoll, error) {
+	resp, err := s.connPool.Select("polls_archive", "primary", 0, 1, tarantool.IterEq, []interface{}{id}, pool.ANY)
+	if err != nil {
+		return nil, fmt.Errorf("tarantool select error: %w", err)
+	}
+
+	archived := convertTuplesToArchivedPolls(resp.Data)
+	if len(archived) == 0 {
+		return nil, ErrNotFound
+	}
+	return archived[0], nil
+}
+
+// ListArchivedPolls lists all archived polls
+func (s *TarantoolStorage) ListArchivedPolls(ctx context.Context) ([]*model.ArchivedPoll, error) {
+	tuples, err := s.selectAll("polls_archive", "primary", tarantool.IterAll, []interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return convertTuplesToArchivedPolls(tuples), nil
+}
+
+// ListExpiredArchive lists archived polls closed no later than before, using the closed_at index
+func (s *TarantoolStorage) ListExpiredArchive(ctx context.Context, before uint64) ([]*model.ArchivedPoll, error) {
+	tuples, err := s.selectAll("polls_archive", "closed_at", tarantool.IterLe, []interface{}{before})
+	if err != nil {
+		return nil, err
+	}
+
+	return convertTuplesToArchivedPolls(tuples), nil
+}
+
+// DeleteArchivedPoll permanently removes an archived poll
+func (s *TarantoolStorage) DeleteArchivedPoll(ctx context.Context, id string) error {
+	slog.Info("Deleting archived poll from Tarantool", "poll_id", id)
+
+	if _, err := s.connPool.Delete("polls_archive", "primary", []interface{}{id}, pool.RW); err != nil {
+		return fmt.Errorf("failed to delete archived poll: %w", err)
+	}
+
+	return nil
+}
+
+// convertTuplesToArchivedPolls converts polls_archive tuples to archived polls, skipping malformed tuples
+func convertTuplesToArchivedPolls(tuples []interface{}) []*model.ArchivedPoll {
+	result := make([]*model.ArchivedPoll, 0, len(tuples))
+
+	for _, tupleData := range tuples {
+		data, ok := tupleData.([]interface{})
+		if !ok || len(data) < 4 {
+			slog.Warn("Invalid archive tuple in Tarantool response", "data", tupleData)
+			continue
+		}
+
+		poll, ok := data[3].([]interface{})
+		if !ok || len(poll) < 7 {
+			slog.Warn("Invalid archived poll in Tarantool response", "data", tupleData)
+			continue
+		}
+
+		result = append(result, &model.ArchivedPoll{
+			Poll:       tupleToPoll(poll),
+			ClosedAt:   uint64(convertToInt64(data[1])),
+			ArchivedAt: uint64(convertToInt64(data[2])),
+		})
+	}
+
+	return result
+}
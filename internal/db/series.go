@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+	"github.com/tarantool/go-tarantool"
+	pool "github.com/tarantool/go-tarantool/connection_pool"
+)
+
+// CreateSeries saves a new recurring series in Tarantool
+func (s *TarantoolStorage) CreateSeries(ctx context.Context, series *model.Series) error {
+	slog.Info("Storing series in Tarantool", "series_id", series.ID)
+
+	_, err := s.connPool.Insert("series", seriesToTuple(series), pool.RW)
+	if err != nil {
+		return fmt.Errorf("failed to insert series: %w", err)
+	}
+
+	return nil
+}
+
+// GetSeries retrieves a recurring series from Tarantool
+func (s *TarantoolStorage) GetSeries(ctx context.Context, id string) (*model.Series, error) {
+	slog.Info("Retrieving series from Tarantool", "series_id", id)
+
+	resp, err := s.connPool.Select("series", "primary", 0, 1, tarantool.IterEq, []interface{}{id}, pool.ANY)
+	if err != nil {
+		return nil, fmt.Errorf("tarantool select error: %w", err)
+	}
+
+	if len(resp.Data) == 0 {
+		return nil, ErrNotFound
+	}
+
+	data, ok := resp.Data[0].([]interface{})
+	if !ok || len(data) < 10 {
+		return nil, fmt.Errorf("invalid Tarantool response")
+	}
+
+	return tupleToSeries(data), nil
+}
+
+// UpdateSeries updates an existing recurring series in Tarantool
+func (s *TarantoolStorage) UpdateSeries(ctx context.Context, series *model.Series) error {
+	slog.Info("Updating series in Tarantool", "series_id", series.ID)
+
+	_, err := s.connPool.Replace("series", seriesToTuple(series), pool.RW)
+	if err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+
+	return nil
+}
+
+// ListSeries lists all recurring series in Tarantool
+func (s *TarantoolStorage) ListSeries(ctx context.Context) ([]*model.Series, error) {
+	resp, err := s.connPool.Select("series", "primary", 0, 1000, tarantool.IterAll, []interface{}{}, pool.ANY)
+	if err != nil {
+		return nil, fmt.Errorf("tarantool select error: %w", err)
+	}
+
+	return convertResponseToSeries(resp), nil
+}
+
+// ListDueSeries lists running series whose next instance is due, using the next_run_at index
+func (s *TarantoolStorage) ListDueSeries(ctx context.Context, now uint64) ([]*model.Series, error) {
+	resp, err := s.connPool.Select("series", "next_run_at", 0, dueLimit, tarantool.IterLe,
+		[]interface{}{now}, pool.ANY)
+	if err != nil {
+		return nil, fmt.Errorf("tarantool select error: %w", err)
+	}
+
+	// the scan runs backwards into stopped series, which have no next run
+	due := make([]*model.Series, 0, len(resp.Data))
+	for _, series := range convertResponseToSeries(resp) {
+		if series.IsStopped() {
+			break
+		}
+		due = append(due, series)
+	}
+
+	return due, nil
+}
+
+// convertResponseToSeries converts a Tarantool response to a slice of series
+func convertResponseToSeries(resp *tarantool.Response) []*model.Series {
+	result := make([]*model.Series, 0, len(resp.Data))
+
+	for _, tupleData := range resp.Data {
+		data, ok := tupleData.([]interface{})
+		if !ok || len(data) < 10 {
+			slog.Warn("Invalid series tuple in Tarantool response", "data", tupleData)
+			continue
+		}
+
+		result = append(result, tupleToSeries(data))
+	}
+
+	return result
+}
+
+// seriesToTuple converts a series to a Tarantool tuple
+func seriesToTuple(series *model.Series) []interface{} {
+	pollIDs := series.PollIDs
+	if pollIDs == nil {
+		pollIDs = []string{}
+	}
+
+	return []interface{}{
+		series.ID,
+		series.Title,
+		series.Options,
+		settingsToMap(series.Settings),
+		series.CreatedBy,
+		series.CreatedAt,
+		series.ChannelID,
+		series.Schedule,
+		series.NextRunAt,
+		pollIDs,
+	}
+}
+
+// tupleToSeries converts a Tarantool tuple to a series
+func tupleToSeries(data []interface{}) *model.Series {
+	return &model.Series{
+		ID:        convertToString(data[0]),
+		Title:     convertToString(data[1]),
+		Options:   convertToStringSlice(data[2]),
+		Settings:  convertToSettings(data[3]),
+		CreatedBy: convertToString(data[4]),
+		CreatedAt: uint64(convertToInt64(data[5])),
+		ChannelID: convertToString(data[6]),
+		Schedule:  convertToString(data[7]),
+		NextRunAt: uint64(convertToInt64(data[8])),
+		PollIDs:   convertToStringSlice(data[9]),
+	}
+}
@@ -16,6 +16,15 @@ var (
 	ErrNotFound = errors.New("record not found")
 )
 
+// dueLimit is the maximum number of overdue polls returned at once
+const dueLimit = 100
+
+// closingLimit is the maximum number of polls closing soon returned at once
+const closingLimit = 1000
+
+// pageSize is the number of tuples fetched per request when a whole index is scanned
+const pageSize = 1000
+
 // Storage defines the methods for working with the poll storage
 type Storage interface {
 	// CreatePoll saves a new poll in Tarantool
@@ -28,6 +37,48 @@ type Storage interface {
 	DeletePoll(ctx context.Context, id string) error
 	// ListPolls lists all polls in Tarantool
 	ListPolls(ctx context.Context) ([]*model.Poll, error)
+	// ListDuePolls lists open polls whose closing time has passed
+	ListDuePolls(ctx context.Context, now uint64) ([]*model.Poll, error)
+	// ListPollsToOpen lists scheduled polls whose opening time has passed
+	ListPollsToOpen(ctx context.Context, now uint64) ([]*model.Poll, error)
+	// ListClosingPolls lists open polls closing after now and no later than until
+	ListClosingPolls(ctx context.Context, now, until uint64) ([]*model.Poll, error)
+	// ListTrashedPolls lists deleted polls waiting in the trash
+	ListTrashedPolls(ctx context.Context) ([]*model.Poll, error)
+	// ListClosedPolls lists closed polls
+	ListClosedPolls(ctx context.Context) ([]*model.Poll, error)
+	// ArchivePoll moves a closed poll to the archive
+	ArchivePoll(ctx context.Context, archived *model.ArchivedPoll) error
+	// GetArchivedPoll retrieves an archived poll
+	GetArchivedPoll(ctx context.Context, id string) (*model.ArchivedPoll, error)
+	// ListArchivedPolls lists all archived polls
+	ListArchivedPolls(ctx context.Context) ([]*model.ArchivedPoll, error)
+	// ListExpiredArchive lists archived polls closed no later than before
+	ListExpiredArchive(ctx context.Context, before uint64) ([]*model.ArchivedPoll, error)
+	// DeleteArchivedPoll permanently removes an archived poll
+	DeleteArchivedPoll(ctx context.Context, id string) error
+	// CreateSeries saves a new recurring series
+	CreateSeries(ctx context.Context, series *model.Series) error
+	// GetSeries retrieves a recurring series
+	GetSeries(ctx context.Context, id string) (*model.Series, error)
+	// UpdateSeries updates an existing recurring series
+	UpdateSeries(ctx context.Context, series *model.Series) error
+	// ListSeries lists all recurring series
+	ListSeries(ctx context.Context) ([]*model.Series, error)
+	// ListDueSeries lists running series whose next instance is due
+	ListDueSeries(ctx context.Context, now uint64) ([]*model.Series, error)
+	// SaveTemplate creates or replaces a poll template
+	SaveTemplate(ctx context.Context, template *model.Template) error
+	// GetTemplate retrieves a poll template, shared templates have an empty owner
+	GetTemplate(ctx context.Context, teamID, owner, name string) (*model.Template, error)
+	// ListTemplates lists the team's poll templates
+	ListTemplates(ctx context.Context, teamID string) ([]*model.Template, error)
+	// DeleteTemplate removes a poll template
+	DeleteTemplate(ctx context.Context, teamID, owner, name string) error
+	// SetRemindersOptOut stops or resumes reminders for the user
+	SetRemindersOptOut(ctx context.Context, userID string, optOut bool) error
+	// IsRemindersOptOut reports whether the user has opted out of reminders
+	IsRemindersOptOut(ctx context.Context, userID string) (bool, error)
 	// Close closes the Tarantool connection
 	Close() error
 }
@@ -39,171 +90,283 @@ type TarantoolStorage struct {
 
 // NewTarantoolStorage creates a new Tarantool storage instance with connection pool
 func NewTarantoolStorage(addr string, opts tarantool.Opts) (*TarantoolStorage, error) {
-    slog.Info("Connecting to Tarantool", "addr", addr)
+	slog.Info("Connecting to Tarantool", "addr", addr)
 
-    poolOpts := pool.OptsPool{
-        CheckTimeout:      1 * time.Second,
-    }
+	poolOpts := pool.OptsPool{
+		CheckTimeout: 1 * time.Second,
+	}
 
-    connPool, err := pool.ConnectWithOpts([]string{addr}, opts, poolOpts)
-    if err != nil {
-        return nil, fmt.Errorf("failed to create connection pool: %w", err)
-    }
+	connPool, err := pool.ConnectWithOpts([]string{addr}, opts, poolOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create connection pool: %w", err)
+	}
 
-    _, err = connPool.Call("box.space.polls:len", []interface{}{}, pool.ANY)
-    if err != nil {
-        connPool.Close()
-        return nil, fmt.Errorf("failed to verify polls space: %w", err)
-    }
+	_, err = connPool.Call("box.space.polls:len", []interface{}{}, pool.ANY)
+	if err != nil {
+		connPool.Close()
+		return nil, fmt.Errorf("failed to verify polls space: %w", err)
+	}
 
-    slog.Info("Successfully connected to Tarantool")
-    return &TarantoolStorage{
-        connPool: connPool,
-    }, nil
+	slog.Info("Successfully connected to Tarantool")
+	return &TarantoolStorage{
+		connPool: connPool,
+	}, nil
 }
 
 // CreatePoll saves a new poll in Tarantool
 func (s *TarantoolStorage) CreatePoll(ctx context.Context, poll *model.Poll) error {
-    slog.Info("Storing poll in Tarantool", "poll_id", poll.ID)
-
-    if poll.CreatedAt == 0 {
-        poll.CreatedAt = uint64(time.Now().Unix())
-    }
-
-    _, err := s.connPool.Insert(
-        "polls",
-        []interface{}{
-            poll.ID, 
-            poll.Title,
-            poll.Options, 
-            poll.CreatedBy,
-            poll.CreatedAt, 
-            poll.IsActive,
-            poll.Votes,
-        },
-        pool.RW,
-    )
-    if err != nil {
-        return fmt.Errorf("failed to insert poll: %w", err)
-    }
-
-    return nil
+	slog.Info("Storing poll in Tarantool", "poll_id", poll.ID)
+
+	if poll.CreatedAt == 0 {
+		poll.CreatedAt = uint64(time.Now().Unix())
+	}
+
+	_, err := s.connPool.Insert(
+		"polls",
+		pollToTuple(poll),
+		pool.RW,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert poll: %w", err)
+	}
+
+	return nil
 }
 
 // GetPoll retrieves a poll from Tarantool
 func (s *TarantoolStorage) GetPoll(ctx context.Context, id string) (*model.Poll, error) {
-    slog.Info("Retrieving poll from Tarantool", "poll_id", id)
-
-    // Используем любое доступное соединение для чтения
-    resp, err := s.connPool.Select("polls", "primary", 0, 1, tarantool.IterEq, []interface{}{id}, pool.ANY)
-    if err != nil {
-        return nil, fmt.Errorf("tarantool select error: %w", err)
-    }
-
-    if len(resp.Data) == 0 {
-        return nil, ErrNotFound
-    }
-
-    data, ok := resp.Data[0].([]interface{})
-    if !ok || len(data) < 7 {
-        return nil, fmt.Errorf("invalid Tarantool response")
-    }
-
-    return &model.Poll{
-        ID:        data[0].(string),
-        Title:     data[1].(string),
-        Options:   convertToStringSlice(data[2]),
-        CreatedBy: data[3].(string),
-        CreatedAt: data[4].(uint64),
-        IsActive:  data[5].(bool),
-        Votes:     convertToMapStringString(data[6]),
-    }, nil
+	slog.Info("Retrieving poll from Tarantool", "poll_id", id)
+
+	// Используем любое доступное соединение для чтения
+	resp, err := s.connPool.Select("polls", "primary", 0, 1, tarantool.IterEq, []interface{}{id}, pool.ANY)
+	if err != nil {
+		return nil, fmt.Errorf("tarantool select error: %w", err)
+	}
+
+	if len(resp.Data) == 0 {
+		return nil, ErrNotFound
+	}
+
+	data, ok := resp.Data[0].([]interface{})
+	if !ok || len(data) < 7 {
+		return nil, fmt.Errorf("invalid Tarantool response")
+	}
+
+	return tupleToPoll(data), nil
 }
 
 // UpdatePoll updates an existing poll in Tarantool
 func (s *TarantoolStorage) UpdatePoll(ctx context.Context, poll *model.Poll) error {
-    slog.Info("Updating poll in Tarantool", "poll_id", poll.ID)
-
-    _, err := s.connPool.Replace(
-        "polls",
-        []interface{}{
-            poll.ID, 
-            poll.Title,
-            poll.Options, 
-            poll.CreatedBy,
-            poll.CreatedAt, 
-            poll.IsActive,
-            poll.Votes,
-        },
-        pool.RW,
-    )
-    if err != nil {
-        return fmt.Errorf("failed to update poll: %w", err)
-    }
-
-    return nil
+	slog.Info("Updating poll in Tarantool", "poll_id", poll.ID)
+
+	_, err := s.connPool.Replace(
+		"polls",
+		pollToTuple(poll),
+		pool.RW,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	return nil
 }
 
 // DeletePoll removes a poll from Tarantool
 func (s *TarantoolStorage) DeletePoll(ctx context.Context, id string) error {
-    slog.Info("Deleting poll from Tarantool", "poll_id", id)
+	slog.Info("Deleting poll from Tarantool", "poll_id", id)
 
-    _, err := s.connPool.Delete("polls", "primary", []interface{}{id}, pool.RW)
-    if err != nil {
-        return fmt.Errorf("failed to delete poll: %w", err)
-    }
+	_, err := s.connPool.Delete("polls", "primary", []interface{}{id}, pool.RW)
+	if err != nil {
+		return fmt.Errorf("failed to delete poll: %w", err)
+	}
 
-    return nil
+	return nil
 }
 
 // ListPolls lists all polls in Tarantool
 func (s *TarantoolStorage) ListPolls(ctx context.Context) ([]*model.Poll, error) {
-    slog.Info("Listing all polls from Tarantool")
+	slog.Info("Listing all polls from Tarantool")
+
+	tuples, err := s.selectAll("polls", "primary", tarantool.IterAll, []interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	return convertTuplesToPolls(tuples), nil
+}
+
+// ListClosedPolls lists closed polls, using the status index
+func (s *TarantoolStorage) ListClosedPolls(ctx context.Context) ([]*model.Poll, error) {
+	tuples, err := s.selectAll("polls", "status", tarantool.IterEq, []interface{}{model.StatusClosed})
+	if err != nil {
+		return nil, err
+	}
+
+	return convertTuplesToPolls(tuples), nil
+}
+
+// selectAll scans the index page by page and returns all matching tuples
+func (s *TarantoolStorage) selectAll(space, index string, iterator uint32, key []interface{}) ([]interface{}, error) {
+	var tuples []interface{}
+
+	for offset := uint32(0); ; offset += pageSize {
+		resp, err := s.connPool.Select(space, index, offset, pageSize, iterator, key, pool.ANY)
+		if err != nil {
+			return nil, fmt.Errorf("tarantool select error: %w", err)
+		}
+
+		tuples = append(tuples, resp.Data...)
+		if len(resp.Data) < pageSize {
+			return tuples, nil
+		}
+	}
+}
+
+// ListDuePolls lists open polls whose closing time has passed, using the closes_at index
+func (s *TarantoolStorage) ListDuePolls(ctx context.Context, now uint64) ([]*model.Poll, error) {
+	resp, err := s.connPool.Select("polls", "closes_at", 0, dueLimit, tarantool.IterLe,
+		[]interface{}{model.StatusOpen, now}, pool.ANY)
+	if err != nil {
+		return nil, fmt.Errorf("tarantool select error: %w", err)
+	}
+
+	polls, err := s.convertResponseToPolls(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// the index is ordered by (status, closes_at), so the scan runs into open polls
+	// without a deadline and then into polls in other states
+	due := make([]*model.Poll, 0, len(polls))
+	for _, poll := range polls {
+		if !poll.IsOpen() || poll.ClosesAt == 0 {
+			break
+		}
+		due = append(due, poll)
+	}
+
+	return due, nil
+}
+
+// ListPollsToOpen lists scheduled polls whose opening time has passed, using the opens_at index
+func (s *TarantoolStorage) ListPollsToOpen(ctx context.Context, now uint64) ([]*model.Poll, error) {
+	resp, err := s.connPool.Select("polls", "opens_at", 0, dueLimit, tarantool.IterLe,
+		[]interface{}{model.StatusScheduled, now}, pool.ANY)
+	if err != nil {
+		return nil, fmt.Errorf("tarantool select error: %w", err)
+	}
+
+	polls, err := s.convertResponseToPolls(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// the index is ordered by (status, opens_at), the scan runs into polls in other states
+	due := make([]*model.Poll, 0, len(polls))
+	for _, poll := range polls {
+		if !poll.IsScheduled() {
+			break
+		}
+		due = append(due, poll)
+	}
+
+	return due, nil
+}
+
+// ListClosingPolls lists open polls closing after now and no later than until, using the closes_at index
+func (s *TarantoolStorage) ListClosingPolls(ctx context.Context, now, until uint64) ([]*model.Poll, error) {
+	resp, err := s.connPool.Select("polls", "closes_at", 0, closingLimit, tarantool.IterGt,
+		[]interface{}{model.StatusOpen, now}, pool.ANY)
+	if err != nil {
+		return nil, fmt.Errorf("tarantool select error: %w", err)
+	}
+
+	polls, err := s.convertResponseToPolls(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	// the index is ordered by (status, closes_at), the scan runs into polls closing later
+	// and then into polls in other states
+	closing := make([]*model.Poll, 0, len(polls))
+	for _, poll := range polls {
+		if !poll.IsOpen() || poll.ClosesAt > until {
+			break
+		}
+		closing = append(closing, poll)
+	}
+
+	return closing, nil
+}
+
+// ListTrashedPolls lists deleted polls waiting in the trash, using the status index
+func (s *TarantoolStorage) ListTrashedPolls(ctx context.Context) ([]*model.Poll, error) {
+	tuples, err := s.selectAll("polls", "status", tarantool.IterEq, []interface{}{model.StatusTrashed})
+	if err != nil {
+		return nil, err
+	}
+
+	return convertTuplesToPolls(tuples), nil
+}
+
+// SetRemindersOptOut stops or resumes reminders for the user
+func (s *TarantoolStorage) SetRemindersOptOut(ctx context.Context, userID string, optOut bool) error {
+	slog.Info("Updating reminder opt-out in Tarantool", "user_id", userID, "opt_out", optOut)
+
+	var err error
+	if optOut {
+		_, err = s.connPool.Replace("reminder_optouts", []interface{}{userID, uint64(time.Now().Unix())}, pool.RW)
+	} else {
+		_, err = s.connPool.Delete("reminder_optouts", "primary", []interface{}{userID}, pool.RW)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update reminder opt-out: %w", err)
+	}
 
-    // Используем любое соединение для чтения
-    resp, err := s.connPool.Select("polls", "primary", 0, 1000, tarantool.IterAll, []interface{}{}, pool.ANY)
-    if err != nil {
-        return nil, fmt.Errorf("tarantool select error: %w", err)
-    }
+	return nil
+}
 
-    return s.convertResponseToPolls(resp)
+// IsRemindersOptOut reports whether the user has opted out of reminders
+func (s *TarantoolStorage) IsRemindersOptOut(ctx context.Context, userID string) (bool, error) {
+	resp, err := s.connPool.Select("reminder_optouts", "primary", 0, 1, tarantool.IterEq,
+		[]interface{}{userID}, pool.ANY)
+	if err != nil {
+		return false, fmt.Errorf("tarantool select error: %w", err)
+	}
+
+	return len(resp.Data) > 0, nil
 }
 
 // convertResponseToPolls converts a Tarantool response to a slice of polls
 func (s *TarantoolStorage) convertResponseToPolls(resp *tarantool.Response) ([]*model.Poll, error) {
-    polls := make([]*model.Poll, 0, len(resp.Data))
+	return convertTuplesToPolls(resp.Data), nil
+}
 
-    for _, tupleData := range resp.Data {
-        data, ok := tupleData.([]interface{})
-        if !ok || len(data) < 7 {
-            slog.Warn("Invalid tuple format in Tarantool response", "data", tupleData)
-            continue
-        }
+// convertTuplesToPolls converts Tarantool tuples to a slice of polls, skipping malformed tuples
+func convertTuplesToPolls(tuples []interface{}) []*model.Poll {
+	polls := make([]*model.Poll, 0, len(tuples))
 
-        poll := &model.Poll{
-            ID:        data[0].(string),
-            Title:     data[1].(string),
-            Options:   convertToStringSlice(data[2]),
-            CreatedBy: data[3].(string),
-            CreatedAt: data[4].(uint64),
-            IsActive:  data[5].(bool),
-            Votes:     convertToMapStringString(data[6]),
-        }
+	for _, tupleData := range tuples {
+		data, ok := tupleData.([]interface{})
+		if !ok || len(data) < 7 {
+			slog.Warn("Invalid tuple format in Tarantool response", "data", tupleData)
+			continue
+		}
 
-        polls = append(polls, poll)
-    }
+		polls = append(polls, tupleToPoll(data))
+	}
 
-    return polls, nil
+	return polls
 }
 
 // Close closes the Tarantool connection pool
 func (s *TarantoolStorage) Close() error {
-    slog.Info("Closing Tarantool connection pool")
-    errs := s.connPool.Close()
-    if len(errs) > 0 {
-        return fmt.Errorf("errors closing Tarantool pool: %v", errs)
-    }
-    return nil
+	slog.Info("Closing Tarantool connection pool")
+	errs := s.connPool.Close()
+	if len(errs) > 0 {
+		return fmt.Errorf("errors closing Tarantool pool: %v", errs)
+	}
+	return nil
 }
 
 // convertToStringSlice is a helper function for converting to string slice
@@ -219,20 +382,435 @@ func convertToStringSlice(value interface{}) []string {
 	return result
 }
 
-// convertToMapString is a helper function for converting to map[string]string
-func convertToMapStringString(value interface{}) map[string]string {
+// convertToUint64Slice is a helper function for converting to uint64 slice
+func convertToUint64Slice(value interface{}) []uint64 {
+	slice, ok := value.([]interface{})
+	if !ok || len(slice) == 0 {
+		return nil
+	}
+	result := make([]uint64, len(slice))
+	for i, v := range slice {
+		result[i] = uint64(convertToInt64(v))
+	}
+	return result
+}
+
+// convertToString is a helper function for converting optional string fields
+func convertToString(value interface{}) string {
+	s, _ := value.(string)
+	return s
+}
+
+// convertToStatus is a helper function for converting the lifecycle state.
+// Polls stored before the lifecycle states have a boolean is_active flag instead
+func convertToStatus(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		if v {
+			return model.StatusOpen
+		}
+		return model.StatusClosed
+	default:
+		return model.StatusClosed
+	}
+}
+
+// pollToTuple converts a poll to a Tarantool tuple
+func pollToTuple(poll *model.Poll) []interface{} {
+	return []interface{}{
+		poll.ID,
+		poll.Title,
+		poll.Options,
+		poll.CreatedBy,
+		poll.CreatedAt,
+		poll.Status,
+		ballotsToMap(poll.Votes),
+		settingsToMap(poll.Settings),
+		suggestionsToArray(poll.Suggestions),
+		tieBreakToMap(poll.TieBreak),
+		poll.ChannelID,
+		poll.ClosesAt,
+		poll.OpensAt,
+		poll.Reminded,
+		poll.SeriesID,
+		poll.ClonedFrom,
+		eventsToArray(poll.Events),
+		editsToArray(poll.Edits),
+		poll.DeletedAt,
+		poll.DeletedFrom,
+		poll.Owners,
+	}
+}
+
+// tupleToPoll converts a Tarantool tuple to a poll.
+// Trailing fields missing in tuples stored by older versions get default values.
+func tupleToPoll(data []interface{}) *model.Poll {
+	return &model.Poll{
+		ID:          data[0].(string),
+		Title:       data[1].(string),
+		Options:     convertToStringSlice(data[2]),
+		CreatedBy:   data[3].(string),
+		CreatedAt:   data[4].(uint64),
+		Status:      convertToStatus(data[5]),
+		Votes:       convertToBallots(data[6]),
+		Settings:    convertToSettings(field(data, 7)),
+		Suggestions: convertToSuggestions(field(data, 8)),
+		TieBreak:    convertToTieBreak(field(data, 9)),
+		ChannelID:   convertToString(field(data, 10)),
+		ClosesAt:    uint64(convertToInt64(field(data, 11))),
+		OpensAt:     uint64(convertToInt64(field(data, 12))),
+		Reminded:    convertToUint64Slice(field(data, 13)),
+		SeriesID:    convertToString(field(data, 14)),
+		ClonedFrom:  convertToString(field(data, 15)),
+		Events:      convertToEvents(field(data, 16)),
+		Edits:       convertToEdits(field(data, 17)),
+		DeletedAt:   uint64(convertToInt64(field(data, 18))),
+		DeletedFrom: convertToString(field(data, 19)),
+		Owners:      convertToStringSlice(field(data, 20)),
+	}
+}
+
+// field returns the tuple field by number or nil if the tuple is shorter
+func field(data []interface{}, i int) interface{} {
+	if i >= len(data) {
+		return nil
+	}
+	return data[i]
+}
+
+// ballotsToMap is a helper function for converting ballots to a msgpack-friendly map
+func ballotsToMap(votes map[string]model.Ballot) map[string]interface{} {
+	result := make(map[string]interface{}, len(votes))
+	for userID, ballot := range votes {
+		result[userID] = map[string]interface{}{
+			"choices": ballot.Choices,
+			"scores":  ballot.Scores,
+			"weight":  ballot.Weight,
+			"cast_at": ballot.CastAt,
+			"reason":  ballot.Reason,
+		}
+	}
+	return result
+}
+
+// convertToBallots is a helper function for converting to map[string]model.Ballot.
+// Polls stored before ballots were introduced map the user ID to the chosen option
+func convertToBallots(value interface{}) map[string]model.Ballot {
 	m, ok := value.(map[interface{}]interface{})
 	if !ok {
 		return nil
 	}
-	result := make(map[string]string)
+	result := make(map[string]model.Ballot)
 	for k, v := range m {
-		key, ok1 := k.(string)
-		val, ok2 := v.(string)
-		if !ok1 || !ok2 {
+		key, ok := k.(string)
+		if !ok {
 			continue
 		}
-		result[key] = val
+		if option, ok := v.(string); ok {
+			result[key] = model.Ballot{Choices: []string{option}}
+			continue
+		}
+		ballot, ok := v.(map[interface{}]interface{})
+		if !ok {
+			slog.Warn("Invalid ballot in Tarantool response")
+			continue
+		}
+		reason, _ := ballot["reason"].(string)
+		result[key] = model.Ballot{
+			Choices: convertToStringSlice(ballot["choices"]),
+			Scores:  convertToMapStringInt(ballot["scores"]),
+			Weight:  convertToFloat64(ballot["weight"]),
+			CastAt:  uint64(convertToInt64(ballot["cast_at"])),
+			Reason:  reason,
+		}
+	}
+	return result
+}
+
+// convertToMapStringInt is a helper function for converting to map[string]int
+func convertToMapStringInt(value interface{}) map[string]int {
+	m, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+	result := make(map[string]int, len(m))
+	for k, v := range m {
+		key, ok := k.(string)
+		if !ok {
+			continue
+		}
+		result[key] = int(convertToInt64(v))
+	}
+	return result
+}
+
+// convertToMapStringFloat is a helper function for converting to map[string]float64
+func convertToMapStringFloat(value interface{}) map[string]float64 {
+	m, ok := value.(map[interface{}]interface{})
+	if !ok || len(m) == 0 {
+		return nil
+	}
+	result := make(map[string]float64, len(m))
+	for k, v := range m {
+		key, ok := k.(string)
+		if !ok {
+			continue
+		}
+		result[key] = convertToFloat64(v)
 	}
 	return result
 }
+
+// settingsToMap is a helper function for converting poll settings to a msgpack-friendly map
+func settingsToMap(settings model.PollSettings) map[string]interface{} {
+	return map[string]interface{}{
+		"type":           settings.Type,
+		"method":         settings.Method,
+		"max_choices":    settings.MaxChoices,
+		"seats":          settings.Seats,
+		"credits":        settings.Credits,
+		"weights":        settings.Weights,
+		"visibility":     settings.Visibility,
+		"quorum":         settings.Quorum,
+		"quorum_percent": settings.QuorumPercent,
+		"threshold":      settings.Threshold,
+		"tie_break":      settings.TieBreak,
+		"vote_policy":    settings.VotePolicy,
+		"duration":       settings.Duration,
+		"change_until":   settings.ChangeUntil,
+		"reminders":      settings.Reminders,
+	}
+}
+
+// convertToSettings is a helper function for converting to model.PollSettings
+func convertToSettings(value interface{}) model.PollSettings {
+	settings := model.PollSettings{
+		Type:       model.PollTypeSingle,
+		Visibility: model.VisibilityConfidential,
+	}
+	m, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return settings
+	}
+	if v, ok := m["type"].(string); ok && v != "" {
+		settings.Type = v
+	}
+	if v, ok := m["method"].(string); ok {
+		settings.Method = v
+	}
+	settings.MaxChoices = int(convertToInt64(m["max_choices"]))
+	settings.Seats = int(convertToInt64(m["seats"]))
+	settings.Credits = int(convertToInt64(m["credits"]))
+	settings.Weights = convertToMapStringFloat(m["weights"])
+	if v, ok := m["visibility"].(string); ok {
+		settings.Visibility = v
+	}
+	settings.Quorum = int(convertToInt64(m["quorum"]))
+	settings.QuorumPercent = int(convertToInt64(m["quorum_percent"]))
+	if v, ok := m["threshold"].(string); ok {
+		settings.Threshold = v
+	}
+	if v, ok := m["tie_break"].(string); ok {
+		settings.TieBreak = v
+	}
+	settings.VotePolicy = model.VotePolicyChange
+	if v, ok := m["vote_policy"].(string); ok && v != "" {
+		settings.VotePolicy = v
+	}
+	settings.ChangeUntil = uint64(convertToInt64(m["change_until"]))
+	settings.Duration = uint64(convertToInt64(m["duration"]))
+	settings.Reminders = convertToUint64Slice(m["reminders"])
+	return settings
+}
+
+// suggestionsToArray is a helper function for converting suggestions to a msgpack-friendly array
+func suggestionsToArray(suggestions []model.Suggestion) []interface{} {
+	result := make([]interface{}, 0, len(suggestions))
+	for _, suggestion := range suggestions {
+		result = append(result, map[string]interface{}{
+			"option":       suggestion.Option,
+			"suggested_by": suggestion.SuggestedBy,
+			"suggested_at": suggestion.SuggestedAt,
+		})
+	}
+	return result
+}
+
+// convertToSuggestions is a helper function for converting to []model.Suggestion
+func convertToSuggestions(value interface{}) []model.Suggestion {
+	slice, ok := value.([]interface{})
+	if !ok || len(slice) == 0 {
+		return nil
+	}
+	result := make([]model.Suggestion, 0, len(slice))
+	for _, v := range slice {
+		m, ok := v.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		option, _ := m["option"].(string)
+		suggestedBy, _ := m["suggested_by"].(string)
+		result = append(result, model.Suggestion{
+			Option:      option,
+			SuggestedBy: suggestedBy,
+			SuggestedAt: uint64(convertToInt64(m["suggested_at"])),
+		})
+	}
+	return result
+}
+
+// eventsToArray is a helper function for converting poll events to a msgpack-friendly array
+func eventsToArray(events []model.PollEvent) []interface{} {
+	result := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		result = append(result, map[string]interface{}{
+			"type":  event.Type,
+			"at":    event.At,
+			"by":    event.By,
+			"until": event.Until,
+		})
+	}
+	return result
+}
+
+// convertToEvents is a helper function for converting to []model.PollEvent
+func convertToEvents(value interface{}) []model.PollEvent {
+	slice, ok := value.([]interface{})
+	if !ok || len(slice) == 0 {
+		return nil
+	}
+	result := make([]model.PollEvent, 0, len(slice))
+	for _, v := range slice {
+		m, ok := v.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		event := model.PollEvent{
+			At:    uint64(convertToInt64(m["at"])),
+			Until: uint64(convertToInt64(m["until"])),
+		}
+		event.Type, _ = m["type"].(string)
+		event.By, _ = m["by"].(string)
+		result = append(result, event)
+	}
+	return result
+}
+
+// editsToArray is a helper function for converting edit records to a msgpack-friendly array
+func editsToArray(edits []model.EditRecord) []interface{} {
+	result := make([]interface{}, 0, len(edits))
+	for _, edit := range edits {
+		result = append(result, map[string]interface{}{
+			"at":            edit.At,
+			"by":            edit.By,
+			"old_title":     edit.OldTitle,
+			"new_title":     edit.NewTitle,
+			"added":         edit.Added,
+			"removed":       edit.Removed,
+			"renamed":       edit.Renamed,
+			"cleared_votes": edit.ClearedVotes,
+		})
+	}
+	return result
+}
+
+// convertToEdits is a helper function for converting to []model.EditRecord
+func convertToEdits(value interface{}) []model.EditRecord {
+	slice, ok := value.([]interface{})
+	if !ok || len(slice) == 0 {
+		return nil
+	}
+	result := make([]model.EditRecord, 0, len(slice))
+	for _, v := range slice {
+		m, ok := v.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		edit := model.EditRecord{
+			At:           uint64(convertToInt64(m["at"])),
+			Added:        convertToStringSlice(m["added"]),
+			Removed:      convertToStringSlice(m["removed"]),
+			Renamed:      convertToMapStringString(m["renamed"]),
+			ClearedVotes: int(convertToInt64(m["cleared_votes"])),
+		}
+		edit.By, _ = m["by"].(string)
+		edit.OldTitle, _ = m["old_title"].(string)
+		edit.NewTitle, _ = m["new_title"].(string)
+		result = append(result, edit)
+	}
+	return result
+}
+
+// convertToMapStringString is a helper function for converting to map[string]string
+func convertToMapStringString(value interface{}) map[string]string {
+	m, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		key, _ := k.(string)
+		result[key], _ = v.(string)
+	}
+	return result
+}
+
+// tieBreakToMap is a helper function for converting a tie-break record to a msgpack-friendly map
+func tieBreakToMap(tieBreak *model.TieBreak) interface{} {
+	if tieBreak == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"policy":    tieBreak.Policy,
+		"tied":      tieBreak.Tied,
+		"winner":    tieBreak.Winner,
+		"seed":      tieBreak.Seed,
+		"runoff_id": tieBreak.RunoffID,
+	}
+}
+
+// convertToTieBreak is a helper function for converting to *model.TieBreak
+func convertToTieBreak(value interface{}) *model.TieBreak {
+	m, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return nil
+	}
+	tieBreak := &model.TieBreak{
+		Tied: convertToStringSlice(m["tied"]),
+		Seed: convertToInt64(m["seed"]),
+	}
+	tieBreak.Policy, _ = m["policy"].(string)
+	tieBreak.Winner, _ = m["winner"].(string)
+	tieBreak.RunoffID, _ = m["runoff_id"].(string)
+	return tieBreak
+}
+
+// convertToInt64 is a helper function for converting msgpack numbers to int64
+func convertToInt64(value interface{}) int64 {
+	switch v := value.(type) {
+	case int64:
+		return v
+	case uint64:
+		return int64(v)
+	case float64:
+		return int64(v)
+	case float32:
+		return int64(v)
+	default:
+		return 0
+	}
+}
+
+// convertToFloat64 is a helper function for converting msgpack numbers to float64
+func convertToFloat64(value interface{}) float64 {
+	switch v := value.(type) {
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	default:
+		return float64(convertToInt64(value))
+	}
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+	"gopkg.in/vmihailenco/msgpack.v2"
+)
+
+// roundTrip encodes the tuple the way the connector sends it and decodes it the way
+// the connector returns it
+func roundTrip(t *testing.T, tuple []interface{}) []interface{} {
+	t.Helper()
+
+	data, err := msgpack.Marshal(tuple)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var decoded []interface{}
+	if err := msgpack.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	return decoded
+}
+
+func TestTupleToPollLegacy(t *testing.T) {
+	tests := []struct {
+		name   string
+		tuple  []interface{}
+		status string
+		votes  map[string]model.Ballot
+	}{
+		{
+			name: "baseline tuple with is_active and string votes",
+			tuple: []interface{}{
+				"poll1", "Favorite color?", []string{"Red", "Green", "Blue"}, "user_a", uint64(1682514732), true,
+				map[string]interface{}{"user_b": "Red", "user_c": "Blue"},
+			},
+			status: model.StatusOpen,
+			votes: map[string]model.Ballot{
+				"user_b": {Choices: []string{"Red"}},
+				"user_c": {Choices: []string{"Blue"}},
+			},
+		},
+		{
+			name: "migrated tuple with string votes and null trailing fields",
+			tuple: []interface{}{
+				"poll2", "Lunch?", []string{"Pizza", "Sushi"}, "user_a", uint64(1682514732), "closed",
+				map[string]interface{}{"user_b": "Sushi"}, nil, nil, nil, "", uint64(0), uint64(1682514732),
+			},
+			status: model.StatusClosed,
+			votes: map[string]model.Ballot{
+				"user_b": {Choices: []string{"Sushi"}},
+			},
+		},
+		{
+			name: "invalid ballots are skipped",
+			tuple: []interface{}{
+				"poll3", "Seed", []string{"Red", "Green"}, "user_a", uint64(1682514732), "open",
+				map[string]interface{}{"Red": 0, "Green": 0},
+			},
+			status: model.StatusOpen,
+			votes:  map[string]model.Ballot{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := tupleToPoll(roundTrip(t, tt.tuple))
+
+			if poll.Status != tt.status {
+				t.Errorf("status = %q, want %q", poll.Status, tt.status)
+			}
+			if !reflect.DeepEqual(poll.Votes, tt.votes) {
+				t.Errorf("votes = %+v, want %+v", poll.Votes, tt.votes)
+			}
+		})
+	}
+}
+
+func TestPollTupleRoundTrip(t *testing.T) {
+	poll := &model.Poll{
+		ID:        "poll1",
+		Title:     "Team lunch",
+		Options:   []string{"Pizza", "Sushi", "Tacos"},
+		CreatedBy: "user_a",
+		CreatedAt: 1700000000,
+		Status:    model.StatusClosed,
+		Votes: map[string]model.Ballot{
+			"user_b": {Choices: []string{"Sushi", "Pizza"}, Weight: 2, CastAt: 1700000100000, Reason: "fresh"},
+			"user_c": {Scores: map[string]int{"Pizza": 5, "Sushi": 0}, Weight: 1.5},
+		},
+		Settings: model.PollSettings{
+			Type:       model.PollTypeRanked,
+			Method:     model.MethodIRV,
+			Visibility: model.VisibilityConfidential,
+			Weights:    map[string]float64{"user_b": 2},
+			Quorum:     3,
+			Threshold:  "majority",
+			TieBreak:   model.TieBreakRandom,
+			VotePolicy: model.VotePolicyChange,
+			Duration:   3600,
+			Reminders:  []uint64{3600},
+		},
+		Suggestions: []model.Suggestion{{Option: "Ramen", SuggestedBy: "user_c", SuggestedAt: 1700000200}},
+		TieBreak:    &model.TieBreak{Policy: model.TieBreakRandom, Tied: []string{"Pizza", "Sushi"}, Winner: "Sushi", Seed: 42},
+		ChannelID:   "channel1",
+		ClosesAt:    1700003600,
+		OpensAt:     1700000000,
+		Reminded:    []uint64{3600},
+		SeriesID:    "series1",
+		ClonedFrom:  "poll0",
+		Events:      []model.PollEvent{{Type: model.EventClosed, At: 1700003600, By: "user_a"}},
+		Edits: []model.EditRecord{{
+			At: 1700000300, By: "user_a", OldTitle: "Lunch", NewTitle: "Team lunch",
+			Added: []string{"Tacos"}, Removed: []string{"Burgers"}, Renamed: map[string]string{"Suhsi": "Sushi"}, ClearedVotes: 1,
+		}},
+		DeletedAt:   0,
+		DeletedFrom: "",
+		Owners:      []string{"user_a", "user_d"},
+	}
+
+	got := tupleToPoll(roundTrip(t, pollToTuple(poll)))
+
+	if !reflect.DeepEqual(got, poll) {
+		t.Errorf("round trip mismatch\n got: %+v\nwant: %+v", got, poll)
+	}
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+	"github.com/tarantool/go-tarantool"
+	pool "github.com/tarantool/go-tarantool/connection_pool"
+)
+
+// SaveTemplate creates or replaces a poll template in Tarantool
+func (s *TarantoolStorage) SaveTemplate(ctx context.Context, template *model.Template) error {
+	slog.Info("Storing template in Tarantool", "team_id", template.TeamID, "name", template.Name)
+
+	_, err := s.connPool.Replace("templates", templateToTuple(template), pool.RW)
+	if err != nil {
+		return fmt.Errorf("failed to save template: %w", err)
+	}
+
+	return nil
+}
+
+// GetTemplate retrieves a poll template from Tarantool. Shared templates have an empty owner
+func (s *TarantoolStorage) GetTemplate(ctx context.Context, teamID, owner, name string) (*model.Template, error) {
+	resp, err := s.connPool.Select("templates", "primary", 0, 1, tarantool.IterEq,
+		[]interface{}{teamID, owner, name}, pool.ANY)
+	if err != nil {
+		return nil, fmt.Errorf("tarantool select error: %w", err)
+	}
+
+	if len(resp.Data) == 0 {
+		return nil, ErrNotFound
+	}
+
+	data, ok := resp.Data[0].([]interface{})
+	if !ok || len(data) < 8 {
+		return nil, fmt.Errorf("invalid Tarantool response")
+	}
+
+	return tupleToTemplate(data), nil
+}
+
+// ListTemplates lists the team's poll templates in Tarantool
+func (s *TarantoolStorage) ListTemplates(ctx context.Context, teamID string) ([]*model.Template, error) {
+	resp, err := s.connPool.Select("templates", "primary", 0, 1000, tarantool.IterEq,
+		[]interface{}{teamID}, pool.ANY)
+	if err != nil {
+		return nil, fmt.Errorf("tarantool select error: %w", err)
+	}
+
+	templates := make([]*model.Template, 0, len(resp.Data))
+	for _, tupleData := range resp.Data {
+		data, ok := tupleData.([]interface{})
+		if !ok || len(data) < 8 {
+			slog.Warn("Invalid template tuple in Tarantool response", "data", tupleData)
+			continue
+		}
+		templates = append(templates, tupleToTemplate(data))
+	}
+
+	return templates, nil
+}
+
+// DeleteTemplate removes a poll template from Tarantool
+func (s *TarantoolStorage) DeleteTemplate(ctx context.Context, teamID, owner, name string) error {
+	slog.Info("Deleting template from Tarantool", "team_id", teamID, "name", name)
+
+	_, err := s.connPool.Delete("templates", "primary", []interface{}{teamID, owner, name}, pool.RW)
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	return nil
+}
+
+// templateToTuple converts a template to a Tarantool tuple
+func templateToTuple(template *model.Template) []interface{} {
+	return []interface{}{
+		template.TeamID,
+		template.Owner,
+		template.Name,
+		template.Title,
+		template.Options,
+		settingsToMap(template.Settings),
+		template.CreatedBy,
+		template.CreatedAt,
+	}
+}
+
+// tupleToTemplate converts a Tarantool tuple to a template
+func tupleToTemplate(data []interface{}) *model.Template {
+	return &model.Template{
+		TeamID:    convertToString(data[0]),
+		Owner:     convertToString(data[1]),
+		Name:      convertToString(data[2]),
+		Title:     convertToString(data[3]),
+		Options:   convertToStringSlice(data[4]),
+		Settings:  convertToSettings(data[5]),
+		CreatedBy: convertToString(data[6]),
+		CreatedAt: uint64(convertToInt64(data[7])),
+	}
+}
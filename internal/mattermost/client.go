@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"strings"
 	"time"
 
@@ -20,14 +21,47 @@ const (
 
 // PollHandler is an polling interface
 type PollHandler interface {
-	CreatePoll(ctx context.Context, title string, options []string, creatorID string) (*domain.Poll, error)
+	CreatePoll(ctx context.Context, title string, options []string, creatorID, channelID string, closesAt uint64, settings domain.PollSettings) (*domain.Poll, error)
 	GetPoll(ctx context.Context, pollID string) (*domain.Poll, error)
-	HandleVote(ctx context.Context, pollID, option, userID string) error
-	GetResults(ctx context.Context, pollID string) (map[string]int, error)
+	HandleVote(ctx context.Context, pollID string, choices []string, reason, userID string) (*domain.Poll, domain.Ballot, error)
+	GetResults(ctx context.Context, pollID string) (map[string]float64, error)
 	EndPoll(ctx context.Context, pollID, userID string) error
-	DeletePoll(ctx context.Context, pollID, userID string) error
+	DeletePoll(ctx context.Context, pollID, userID string) (uint64, error)
+	RestorePoll(ctx context.Context, pollID, userID string) (*domain.Poll, error)
+	FormatTrash(ctx context.Context, userID string) (string, error)
+	EnforceRetention(ctx context.Context, userID string) (int, int, error)
+	SearchArchive(ctx context.Context, query, userID string) (string, error)
+	FormatArchivedPoll(ctx context.Context, pollID, userID string) (string, error)
+	AddOwner(ctx context.Context, pollID, ownerID, userID string) (*domain.Poll, error)
+	RemoveOwner(ctx context.Context, pollID, ownerID, userID string) (*domain.Poll, error)
+	TransferOwnership(ctx context.Context, pollID, ownerID, userID string) (*domain.Poll, error)
 	ListPolls(ctx context.Context) ([]*domain.Poll, error)
 	FormatPollResults(ctx context.Context, pollID string) (string, error)
+	GetVoters(ctx context.Context, pollID string) (map[string][]string, error)
+	SuggestOption(ctx context.Context, pollID, option, userID string) (*domain.Poll, error)
+	ApproveSuggestion(ctx context.Context, pollID string, number int, userID string) (domain.Suggestion, error)
+	RejectSuggestion(ctx context.Context, pollID string, number int, userID string) (domain.Suggestion, error)
+	PickWinner(ctx context.Context, pollID, option, userID string) (*domain.Poll, error)
+	RetractVote(ctx context.Context, pollID, userID string) (*domain.Poll, error)
+	CreateDraft(ctx context.Context, title string, options []string, creatorID, channelID string, closesAt uint64, settings domain.PollSettings) (*domain.Poll, error)
+	PublishPoll(ctx context.Context, pollID, userID string) (*domain.Poll, error)
+	SchedulePoll(ctx context.Context, pollID string, opensAt uint64, userID string) (*domain.Poll, error)
+	EditPoll(ctx context.Context, pollID string, edit domain.PollEdit, userID string) (*domain.Poll, error)
+	FormatEditHistory(ctx context.Context, pollID string) (string, error)
+	ListDrafts(ctx context.Context, userID string) ([]*domain.Poll, error)
+	SetRemindersOptOut(ctx context.Context, userID string, optOut bool) error
+	CreateSeries(ctx context.Context, schedule, title string, options []string, creatorID, channelID string, settings domain.PollSettings) (*domain.Series, error)
+	StopSeries(ctx context.Context, seriesID, userID string) (*domain.Series, error)
+	ListSeries(ctx context.Context, channelID string) ([]*domain.Series, error)
+	FormatSeriesHistory(ctx context.Context, seriesID string) (string, error)
+	SaveTemplate(ctx context.Context, teamID, name, title string, options []string, settings domain.PollSettings, private bool, userID string) (*domain.Template, error)
+	GetTemplate(ctx context.Context, teamID, name, userID string) (*domain.Template, error)
+	ListTemplates(ctx context.Context, teamID, userID string) ([]*domain.Template, error)
+	DeleteTemplate(ctx context.Context, teamID, name, userID string) (*domain.Template, error)
+	ClonePoll(ctx context.Context, pollID, channelID string, quorum int, userID string) (*domain.Poll, error)
+	FormatComparison(ctx context.Context, pollID string) (string, error)
+	ReopenPoll(ctx context.Context, pollID string, duration uint64, userID string) (*domain.Poll, error)
+	ExtendPoll(ctx context.Context, pollID string, duration uint64, userID string) (*domain.Poll, error)
 }
 
 // Client provides a client for work with Mattermost API
@@ -38,6 +72,7 @@ type Client struct {
 	webSocketClient *model.WebSocketClient
 	pollHandler     PollHandler
 	httpHandler     *api.HTTPHandler
+	users           *userCache
 }
 
 type MattermostAPI interface {
@@ -96,6 +131,7 @@ func NewClient(cfg config.MattermostConfig, handler PollHandler) (*Client, error
 		webSocketClient: wsClient,
 		pollHandler:     handler,
 		handlers:        make(map[string]CommandHandler),
+		users:           newUserCache(),
 	}
 
 	client.RegisterCommandHandlers()
@@ -136,10 +172,28 @@ func (c *Client) reconnectWebSocket() {
 func (c *Client) RegisterCommandHandlers() {
 	c.RegisterCommandHandler("poll-create", c.handlePollCreate)
 	c.RegisterCommandHandler("poll-vote", c.handlePollVote)
+	c.RegisterCommandHandler("poll-unvote", c.handlePollUnvote)
 	c.RegisterCommandHandler("poll-results", c.handlePollResults)
 	c.RegisterCommandHandler("poll-end", c.handlePollEnd)
 	c.RegisterCommandHandler("poll-delete", c.handlePollDelete)
 	c.RegisterCommandHandler("poll-list", c.handlePollList)
+	c.RegisterCommandHandler("poll-suggest", c.handlePollSuggest)
+	c.RegisterCommandHandler("poll-approve", c.handlePollApprove)
+	c.RegisterCommandHandler("poll-reject", c.handlePollReject)
+	c.RegisterCommandHandler("poll-tiebreak", c.handlePollTieBreak)
+	c.RegisterCommandHandler("poll-edit", c.handlePollEdit)
+	c.RegisterCommandHandler("poll-publish", c.handlePollPublish)
+	c.RegisterCommandHandler("poll-schedule", c.handlePollSchedule)
+	c.RegisterCommandHandler("poll-reminders", c.handlePollReminders)
+	c.RegisterCommandHandler("poll-recur", c.handlePollRecur)
+	c.RegisterCommandHandler("poll-template", c.handlePollTemplate)
+	c.RegisterCommandHandler("poll-clone", c.handlePollClone)
+	c.RegisterCommandHandler("poll-reopen", c.handlePollReopen)
+	c.RegisterCommandHandler("poll-extend", c.handlePollExtend)
+	c.RegisterCommandHandler("poll-trash", c.handlePollTrash)
+	c.RegisterCommandHandler("poll-restore", c.handlePollRestore)
+	c.RegisterCommandHandler("poll-admin", c.handlePollAdmin)
+	c.RegisterCommandHandler("poll-owner", c.handlePollOwner)
 }
 
 // RegisterCommandHandler registers command handler
@@ -159,26 +213,34 @@ func (c *Client) RegisterCommands(cfg config.MattermostConfig) error {
 			Trigger:          "poll-create",
 			Method:           "P",
 			AutoComplete:     true,
-			AutoCompleteDesc: "Create a new poll: /poll-create \"Title\" \"Option 1\" \"Option 2\" ...",
-			AutoCompleteHint: "Title \"Option 1\" \"Option 2\" ...",
+			AutoCompleteDesc: "Create a new poll: /poll-create \"Title\" \"Option 1\" \"Option 2\" ... [--draft] [--type ranked|approval|score|stv|quadratic] [--method irv|borda|schulze] [--max-choices N] [--seats N] [--credits N] [--weights \"user=2,...\"] [--weight-group \"group=2,...\"] [--anonymous|--public] [--quorum N|N%] [--threshold majority|two-thirds|N%] [--tiebreak creator|random|earliest|runoff] [--lock-votes|--change-until TIME] [--closes-in 2h|--closes-at TIME] [--remind 24h,1h]",
+			AutoCompleteHint: "Title \"Option 1\" \"Option 2\" ... [--draft] [--type ranked|approval|score|stv|quadratic] [--method irv|borda|schulze] [--max-choices N] [--seats N] [--credits N] [--weights \"user=2,...\"] [--weight-group \"group=2,...\"] [--anonymous|--public] [--quorum N|N%] [--threshold majority|two-thirds|N%] [--tiebreak creator|random|earliest|runoff] [--lock-votes|--change-until TIME] [--closes-in 2h|--closes-at TIME] [--remind 24h,1h]",
 			URL:              commandsEndpoint,
 		},
 		{
 			Trigger:          "poll-vote",
 			Method:           "P",
 			AutoComplete:     true,
-			AutoCompleteDesc: "Vote in a poll: /poll-vote poll-id option",
-			AutoCompleteHint: "poll-id option",
+			AutoCompleteDesc: "Vote in a poll: /poll-vote poll-id option (ranked polls: options in order of preference, approval polls: all options you approve, score polls: option=score, quadratic polls: option=votes), add --because \"reason\" to explain your choice",
+			AutoCompleteHint: "poll-id option [option ...] [--because \"reason\"]",
 			URL:              commandsEndpoint,
 		},
 		{
-			Trigger:          "poll-results",
+			Trigger:          "poll-unvote",
 			Method:           "P",
 			AutoComplete:     true,
-			AutoCompleteDesc: "Show poll results: /poll-results poll-id",
+			AutoCompleteDesc: "Retract your vote: /poll-unvote poll-id (if the poll allows changing votes)",
 			AutoCompleteHint: "poll-id",
 			URL:              commandsEndpoint,
 		},
+		{
+			Trigger:          "poll-results",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Show poll results: /poll-results poll-id [--voters] [--compare] (voters are listed for public polls only, --compare shows a cloned poll next to the original)",
+			AutoCompleteHint: "poll-id [--voters] [--compare]",
+			URL:              commandsEndpoint,
+		},
 		{
 			Trigger:          "poll-end",
 			Method:           "P",
@@ -191,7 +253,7 @@ func (c *Client) RegisterCommands(cfg config.MattermostConfig) error {
 			Trigger:          "poll-delete",
 			Method:           "P",
 			AutoComplete:     true,
-			AutoCompleteDesc: "Delete a poll: /poll-delete poll-id",
+			AutoCompleteDesc: "Move a poll to the trash, it can be restored with /poll-restore until it is purged: /poll-delete poll-id",
 			AutoCompleteHint: "poll-id",
 			URL:              commandsEndpoint,
 		},
@@ -199,8 +261,143 @@ func (c *Client) RegisterCommands(cfg config.MattermostConfig) error {
 			Trigger:          "poll-list",
 			Method:           "P",
 			AutoComplete:     true,
-			AutoCompleteDesc: "List all active polls",
-			AutoCompleteHint: "",
+			AutoCompleteDesc: "List all polls, --drafts lists your drafts and scheduled polls",
+			AutoCompleteHint: "[--drafts]",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-suggest",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Suggest a new option: /poll-suggest poll-id \"Option\" (without an option lists pending suggestions)",
+			AutoCompleteHint: "poll-id [\"Option\"]",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-approve",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Add a suggested option to your poll: /poll-approve poll-id suggestion-number",
+			AutoCompleteHint: "poll-id suggestion-number",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-reject",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Reject a suggested option: /poll-reject poll-id suggestion-number",
+			AutoCompleteHint: "poll-id suggestion-number",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-tiebreak",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Pick the winner of your tied poll: /poll-tiebreak poll-id \"Option\"",
+			AutoCompleteHint: "poll-id \"Option\"",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-edit",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Edit your poll, votes for renamed options are kept and votes for removed ones cleared: /poll-edit poll-id [--title \"Title\"] [--add \"Option\"] [--remove \"Option\"] [--rename \"Old=New\"] (--add, --remove and --rename can be repeated), or /poll-edit poll-id --history",
+			AutoCompleteHint: "poll-id [--title \"Title\"] [--add \"Option\"] [--remove \"Option\"] [--rename \"Old=New\"] | poll-id --history",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-publish",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Open your draft or scheduled poll now: /poll-publish poll-id",
+			AutoCompleteHint: "poll-id",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-schedule",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Schedule your draft to open later: /poll-schedule poll-id TIME (a duration like 2h or 1d, or a UTC time like \"2025-06-01 09:00\")",
+			AutoCompleteHint: "poll-id TIME",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-reminders",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Turn reminder DMs about polls you haven't voted in off or back on: /poll-reminders off|on",
+			AutoCompleteHint: "off|on",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-recur",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Open a fresh poll on a cron schedule (UTC), closing the previous one: /poll-recur \"0 12 * * fri\" \"Title\" \"Option 1\" \"Option 2\" ... [poll-create flags], or /poll-recur list|stop ID|history ID",
+			AutoCompleteHint: "\"SCHEDULE\" \"Title\" \"Option 1\" \"Option 2\" ... | list | stop series-id | history series-id",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-template",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Reuse common polls: /poll-template save name \"Title\" \"Option 1\" \"Option 2\" ... [--private] [poll-create flags], /poll-template use name, /poll-template list, /poll-template delete name",
+			AutoCompleteHint: "save name \"Title\" \"Option 1\" ... [--private] | use name | list | delete name",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-clone",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Run a poll again with the same title, options and settings: /poll-clone poll-id [--channel ~channel]",
+			AutoCompleteHint: "poll-id [--channel ~channel]",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-reopen",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Reopen your closed poll keeping its votes: /poll-reopen poll-id [--for 24h]",
+			AutoCompleteHint: "poll-id [--for 24h]",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-extend",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Move the closing time of your poll later: /poll-extend poll-id 2h",
+			AutoCompleteHint: "poll-id duration",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-trash",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "List your deleted polls and when they are purged",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-restore",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Restore your deleted poll from the trash: /poll-restore poll-id",
+			AutoCompleteHint: "poll-id",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-admin",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "System admins only: /poll-admin retention archives and purges old polls now, /poll-admin archive search \"query\", /poll-admin archive show poll-id",
+			AutoCompleteHint: "retention | archive search \"query\" | archive show poll-id",
+			URL:              commandsEndpoint,
+		},
+		{
+			Trigger:          "poll-owner",
+			Method:           "P",
+			AutoComplete:     true,
+			AutoCompleteDesc: "Share your poll with co-owners or hand it over: /poll-owner add|remove|transfer poll-id @user",
+			AutoCompleteHint: "add|remove|transfer poll-id @user",
 			URL:              commandsEndpoint,
 		},
 	}
@@ -217,11 +414,16 @@ func (c *Client) RegisterCommands(cfg config.MattermostConfig) error {
 			slog.Error("Failed to get existing commands", "team", team.Name, "error", err)
 		}
 
-		if len(existingCommands) > 0 {
-			continue
+		registered := make(map[string]bool, len(existingCommands))
+		for _, cmd := range existingCommands {
+			registered[cmd.Trigger] = true
 		}
 
 		for _, cmd := range commands {
+			if registered[cmd.Trigger] {
+				continue
+			}
+
 			cmd.TeamId = team.Id
 			cmd.CreatorId = c.botUser.Id
 
@@ -230,7 +432,7 @@ func (c *Client) RegisterCommands(cfg config.MattermostConfig) error {
 				return fmt.Errorf("failed to register command %s: %w", cmd.Trigger, err)
 			}
 
-			slog.Info("Registered command", "trigger", cmd.Trigger)
+			slog.Info("Registered command", "trigger", cmd.Trigger, "team", team.Name)
 		}
 	}
 	return nil
@@ -305,8 +507,10 @@ func (c *Client) handleSlashCommand(event *model.WebSocketEvent) {
 
 // handlePollCreate handles the creation of the poll
 func (c *Client) handlePollCreate(args []string, userID, channelID string) (string, error) {
+	args, flags := parseFlags(args, "anonymous", "public", "lock-votes", "draft")
+
 	if len(args) < 3 {
-		return "Usage: `/poll-create \"Title\" \"Option 1\" \"Option 2\" ...`\nTitle" +
+		return "Usage: `/poll-create \"Title\" \"Option 1\" \"Option 2\" ... [--draft] [--type ranked|approval|score|stv|quadratic] [--method irv|borda|schulze] [--max-choices N] [--seats N] [--credits N] [--weights \"user=2,...\"] [--weight-group \"group=2,...\"] [--anonymous|--public] [--quorum N|N%] [--threshold majority|two-thirds|N%] [--tiebreak creator|random|earliest|runoff] [--lock-votes|--change-until TIME] [--closes-in 2h|--closes-at TIME] [--remind 24h,1h]`\nTitle " +
 			"and at least 2 options enclosed with \"\" are required.", nil
 	}
 
@@ -317,56 +521,395 @@ func (c *Client) handlePollCreate(args []string, userID, channelID string) (stri
 		return "Error: Please provide a title and at least 2 options.", nil
 	}
 
-	slog.Info("Creating poll", "title", title, "options", options)
+	settings, closesAt, message := c.parsePollSettings(flags, channelID)
+	if message != "" {
+		return message, nil
+	}
+
+	slog.Info("Creating poll", "title", title, "options", options, "type", settings.Type)
 
 	ctx := context.Background()
-	poll, err := c.pollHandler.CreatePoll(ctx, title, options, userID)
+
+	if flags["draft"] == "true" {
+		poll, err := c.pollHandler.CreateDraft(ctx, title, options, userID, channelID, closesAt, settings)
+		if err != nil {
+			return "", fmt.Errorf("failed to create draft: %w", err)
+		}
+
+		return fmt.Sprintf("### Draft Created: %s\n\n**ID:** %s\n\n%s\n"+
+			"The draft is not listed and can't be voted on yet.\n"+
+			"To change it: `/poll-edit %s --title \"New title\" --add \"Option\" --remove \"Option\" --rename \"Old=New\"`\n"+
+			"To open it now: `/poll-publish %s`\n"+
+			"To open it later: `/poll-schedule %s TIME`",
+			poll.Title, poll.ID, describePoll(poll), poll.ID, poll.ID, poll.ID), nil
+	}
+
+	poll, err := c.pollHandler.CreatePoll(ctx, title, options, userID, channelID, closesAt, settings)
 	if err != nil {
 		return "", fmt.Errorf("failed to create poll: %w", err)
 	}
 
-	response := fmt.Sprintf("### Poll Created: %s\n\n**ID:** %s\n\n**Options:**\n", poll.Title, poll.ID)
-	for i, option := range poll.Options {
-		response += fmt.Sprintf("%d. %s\n", i+1, option)
+	return fmt.Sprintf("### Poll Created: %s\n\n**ID:** %s\n\n%s%s", poll.Title, poll.ID, describePoll(poll), voteHint(poll)), nil
+}
+
+// parsePollSettings parses the flags shared by the commands creating polls into poll settings
+// and an absolute closing time. Invalid flags are reported with a message for the user
+func (c *Client) parsePollSettings(flags map[string]string, channelID string) (domain.PollSettings, uint64, string) {
+	settings := domain.PollSettings{
+		Type:   flags["type"],
+		Method: flags["method"],
 	}
 
-	response += fmt.Sprintf("\nTo vote: `/poll-vote %s \"Option\"`\nTo see results: `/poll-results %s`", poll.ID, poll.ID)
+	switch {
+	case flags["anonymous"] == "true" && flags["public"] == "true":
+		return settings, 0, "Error: a poll can't be both anonymous and public."
+	case flags["anonymous"] == "true":
+		settings.Visibility = domain.VisibilityAnonymous
+	case flags["public"] == "true":
+		settings.Visibility = domain.VisibilityPublic
+	}
 
-	return response, nil
+	if value, ok := flags["max-choices"]; ok {
+		maxChoices, err := strconv.Atoi(value)
+		if err != nil || maxChoices < 1 {
+			return settings, 0, "Error: `--max-choices` must be a positive number."
+		}
+		settings.MaxChoices = maxChoices
+	}
+
+	if value, ok := flags["seats"]; ok {
+		seats, err := strconv.Atoi(value)
+		if err != nil || seats < 1 {
+			return settings, 0, "Error: `--seats` must be a positive number."
+		}
+		settings.Seats = seats
+	}
+
+	if value, ok := flags["credits"]; ok {
+		credits, err := strconv.Atoi(value)
+		if err != nil || credits < 1 {
+			return settings, 0, "Error: `--credits` must be a positive number."
+		}
+		settings.Credits = credits
+	}
+
+	if value, ok := flags["quorum"]; ok {
+		quorum, percent, err := c.resolveQuorum(value, channelID)
+		if err != nil {
+			return settings, 0, fmt.Sprintf("Error: %v", err)
+		}
+		settings.Quorum = quorum
+		settings.QuorumPercent = percent
+	}
+
+	settings.Threshold = flags["threshold"]
+	settings.TieBreak = flags["tiebreak"]
+
+	if value, ok := flags["change-until"]; ok {
+		if flags["lock-votes"] == "true" {
+			return settings, 0, "Error: `--lock-votes` and `--change-until` can't be used together."
+		}
+		cutoff, err := parseTime(value, time.Now())
+		if err != nil {
+			return settings, 0, fmt.Sprintf("Error: %v", err)
+		}
+		settings.VotePolicy = domain.VotePolicyWindow
+		settings.ChangeUntil = uint64(cutoff.Unix())
+	} else if flags["lock-votes"] == "true" {
+		settings.VotePolicy = domain.VotePolicyLock
+	}
+
+	var closesAt uint64
+	if value, ok := flags["closes-at"]; ok {
+		if _, ok := flags["closes-in"]; ok {
+			return settings, 0, "Error: `--closes-in` and `--closes-at` can't be used together."
+		}
+		deadline, err := parseTime(value, time.Now())
+		if err != nil {
+			return settings, 0, fmt.Sprintf("Error: %v", err)
+		}
+		closesAt = uint64(deadline.Unix())
+	} else if value, ok := flags["closes-in"]; ok {
+		duration, err := parseDuration(value)
+		if err != nil {
+			return settings, 0, fmt.Sprintf("Error: %v", err)
+		}
+		settings.Duration = uint64(duration / time.Second)
+	}
+
+	if value, ok := flags["remind"]; ok {
+		for _, item := range strings.Split(value, ",") {
+			offset, err := parseDuration(strings.TrimSpace(item))
+			if err != nil {
+				return settings, 0, fmt.Sprintf("Error: `--remind`: %v", err)
+			}
+			settings.Reminders = append(settings.Reminders, uint64(offset/time.Second))
+		}
+	}
+
+	weights, err := c.resolveWeights(flags)
+	if err != nil {
+		return settings, 0, fmt.Sprintf("Error: invalid weights: %v", err)
+	}
+	settings.Weights = weights
+
+	return settings, closesAt, ""
+}
+
+// describePoll describes the poll's rules and lists its options
+func describePoll(poll *domain.Poll) string {
+	response := ""
+	switch {
+	case poll.IsSTV():
+		response += fmt.Sprintf("**Type:** single transferable vote, %d seat(s)\n\n", poll.Settings.Seats)
+	case poll.IsRanked():
+		response += fmt.Sprintf("**Type:** ranked (%s)\n\n", poll.Settings.Method)
+	case poll.IsApproval() && poll.Settings.MaxChoices > 0:
+		response += fmt.Sprintf("**Type:** approval (up to %d options)\n\n", poll.Settings.MaxChoices)
+	case poll.IsApproval():
+		response += "**Type:** approval (any number of options)\n\n"
+	case poll.IsScored():
+		response += fmt.Sprintf("**Type:** score (%d–%d, STAR runoff)\n\n", domain.MinScore, domain.MaxScore)
+	case poll.IsQuadratic():
+		response += fmt.Sprintf("**Type:** quadratic (%d voice credits per voter, k votes cost k² credits)\n\n",
+			poll.Settings.Credits)
+	}
+
+	if poll.IsAnonymous() {
+		response += "**Anonymous poll:** only pseudonymous vote keys are stored, nobody can see who voted for what\n\n"
+	}
+
+	if poll.IsDecision() {
+		response += "**Decision poll:**"
+		if poll.Settings.Quorum > 0 {
+			response += fmt.Sprintf(" quorum of %d voters", poll.Settings.Quorum)
+		}
+		if poll.Settings.Threshold != "" {
+			if poll.Settings.Quorum > 0 {
+				response += ","
+			}
+			response += fmt.Sprintf(" %s threshold", poll.Settings.Threshold)
+		}
+		response += ". The outcome is announced when the poll is closed\n\n"
+	}
+
+	if poll.Settings.TieBreak != "" {
+		response += fmt.Sprintf("**Tie-break:** %s\n\n", poll.Settings.TieBreak)
+	}
+
+	response += fmt.Sprintf("**Vote changes:** %s\n\n", describeVotePolicy(poll))
+
+	switch {
+	case poll.ClosesAt != 0:
+		response += fmt.Sprintf("**Closes at:** %s, the results will be posted here\n\n", domain.FormatTime(poll.ClosesAt))
+	case poll.Settings.Duration > 0:
+		response += fmt.Sprintf("**Open for:** %s after opening, the results will be posted here\n\n",
+			formatDuration(time.Duration(poll.Settings.Duration)*time.Second))
+	}
+
+	if len(poll.Settings.Reminders) > 0 {
+		offsets := make([]string, 0, len(poll.Settings.Reminders))
+		for _, offset := range poll.Settings.Reminders {
+			offsets = append(offsets, formatDuration(time.Duration(offset)*time.Second))
+		}
+		response += fmt.Sprintf("**Reminders:** channel members who haven't voted get a DM %s before closing "+
+			"(`/poll-reminders off` to opt out)\n\n", strings.Join(offsets, " and "))
+	}
+
+	if poll.IsPublic() {
+		response += "**Public poll:** everyone can see who voted for what with `/poll-results " + poll.ID + " --voters`\n\n"
+	}
+
+	if poll.IsWeighted() {
+		response += fmt.Sprintf("**Weighted:** %d voter(s) with custom weights, everyone else weighs 1\n\n",
+			len(poll.Settings.Weights))
+	}
+
+	response += "**Options:**\n" + formatOptions(poll.Options)
+
+	return response
+}
+
+// formatOptions lists the options with their numbers
+func formatOptions(options []string) string {
+	formatted := ""
+	for i, option := range options {
+		formatted += fmt.Sprintf("%d. %s\n", i+1, option)
+	}
+	return formatted
+}
+
+// voteHint tells how to vote in the poll and see its results
+func voteHint(poll *domain.Poll) string {
+	var response string
+	switch {
+	case poll.IsRanked():
+		response += fmt.Sprintf("\nTo vote: `/poll-vote %s \"First choice\" \"Second choice\" ...`", poll.ID)
+	case poll.IsApproval():
+		response += fmt.Sprintf("\nTo vote: `/poll-vote %s \"Option\" \"Another option\" ...`", poll.ID)
+	case poll.IsScored():
+		response += fmt.Sprintf("\nTo vote: `/poll-vote %s \"Option=%d\" \"Another option=%d\" ...`",
+			poll.ID, domain.MaxScore, domain.MinScore)
+	case poll.IsQuadratic():
+		response += fmt.Sprintf("\nTo vote: `/poll-vote %s \"Option=3\" \"Another option=-1\" ...`", poll.ID)
+	default:
+		response += fmt.Sprintf("\nTo vote: `/poll-vote %s \"Option\"`", poll.ID)
+	}
+	response += fmt.Sprintf("\nTo see results: `/poll-results %s`", poll.ID)
+
+	return response
 }
 
 // handlePollVote handles poll voting
 func (c *Client) handlePollVote(args []string, userID, channelID string) (string, error) {
+	args, flags := parseFlags(args)
+
 	if len(args) < 2 {
-		return "Usage: `/poll-vote [poll-id] [option]`", nil
+		return "Usage: `/poll-vote [poll-id] [option]` or `/poll-vote [poll-id] [option] [option] ...` for ranked and approval polls, `/poll-vote [poll-id] [option=score] ...` for score polls, `/poll-vote [poll-id] [option=votes] ...` for quadratic polls. Add `--because \"reason\"` to explain your choice", nil
 	}
 
 	pollID := args[0]
-	option := args[1]
+	choices := args[1:]
 
 	ctx := context.Background()
-	if err := c.pollHandler.HandleVote(ctx, pollID, option, userID); err != nil {
+	poll, ballot, err := c.pollHandler.HandleVote(ctx, pollID, choices, flags["because"], userID)
+	if err != nil {
 		return "", fmt.Errorf("failed to vote: %w", err)
 	}
 
-	return fmt.Sprintf("Your vote for **%s** in poll **%s** has been recorded.", option, pollID), nil
+	return fmt.Sprintf("Your vote for **%s** in poll **%s** has been recorded.\n\n%s",
+		formatBallot(poll, ballot), pollID, describeVotePolicy(poll)), nil
+}
+
+// formatBallot describes the options chosen on the ballot
+func formatBallot(poll *domain.Poll, ballot domain.Ballot) string {
+	if poll.IsScored() || poll.IsQuadratic() {
+		assignments := make([]string, 0, len(ballot.Scores))
+		for _, option := range poll.Options {
+			if value, ok := ballot.Scores[option]; ok && (value != 0 || poll.IsScored()) {
+				assignments = append(assignments, fmt.Sprintf("%s=%d", option, value))
+			}
+		}
+		return strings.Join(assignments, ", ")
+	}
+
+	separator := ", "
+	if poll.IsRanked() {
+		separator = " > "
+	}
+	return strings.Join(ballot.Choices, separator)
+}
+
+// handlePollUnvote retracts the user's vote
+func (c *Client) handlePollUnvote(args []string, userID, channelID string) (string, error) {
+	if len(args) < 1 {
+		return "Usage: `/poll-unvote [poll-id]`", nil
+	}
+
+	pollID := args[0]
+
+	poll, err := c.pollHandler.RetractVote(context.Background(), pollID, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to retract vote: %w", err)
+	}
+
+	return fmt.Sprintf("Your vote in poll **%s** has been retracted.", poll.Title), nil
+}
+
+// describeVotePolicy tells voters whether they can change their vote
+func describeVotePolicy(poll *domain.Poll) string {
+	switch poll.Settings.VotePolicy {
+	case domain.VotePolicyLock:
+		return "Votes in this poll are final and can't be changed or retracted."
+	case domain.VotePolicyWindow:
+		return fmt.Sprintf("Votes can be changed or retracted with `/poll-unvote %s` until %s.",
+			poll.ID, domain.FormatTime(poll.Settings.ChangeUntil))
+	default:
+		return fmt.Sprintf("Votes can be changed any time while the poll is open, or retracted with `/poll-unvote %s`.", poll.ID)
+	}
 }
 
 // handlePollResults handles results display of the poll
 func (c *Client) handlePollResults(args []string, userID, channelID string) (string, error) {
+	args, flags := parseFlags(args, "voters", "compare")
+
 	if len(args) < 1 {
-		return "Usage: `/poll-results [poll-id]`", nil
+		return "Usage: `/poll-results [poll-id] [--voters] [--compare]`", nil
 	}
 
 	pollID := args[0]
 	ctx := context.Background()
 
+	if flags["compare"] == "true" {
+		comparison, err := c.pollHandler.FormatComparison(ctx, pollID)
+		if err != nil {
+			return "", fmt.Errorf("failed to compare poll results: %w", err)
+		}
+		return comparison, nil
+	}
+
 	results, err := c.pollHandler.FormatPollResults(ctx, pollID)
 	if err != nil {
 		return "", fmt.Errorf("failed to get poll results: %w", err)
 	}
 
-	return results, nil
+	if flags["voters"] != "true" {
+		return results, nil
+	}
+
+	voters, err := c.formatVoters(ctx, pollID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get poll voters: %w", err)
+	}
+
+	return results + "\n" + voters, nil
+}
+
+// formatVoters lists the voters of every option of a public poll by username
+func (c *Client) formatVoters(ctx context.Context, pollID string) (string, error) {
+	poll, err := c.pollHandler.GetPoll(ctx, pollID)
+	if err != nil {
+		return "", err
+	}
+
+	voters, err := c.pollHandler.GetVoters(ctx, pollID)
+	if err != nil {
+		return "", err
+	}
+
+	var userIDs []string
+	for _, ids := range voters {
+		userIDs = append(userIDs, ids...)
+	}
+
+	names, err := c.usernames(userIDs)
+	if err != nil {
+		return "", err
+	}
+
+	response := "#### Voters:\n"
+	if poll.IsRanked() {
+		response = "#### Voters (first preferences):\n"
+	}
+
+	for _, option := range poll.Options {
+		mentions := make([]string, 0, len(voters[option]))
+		for _, id := range voters[option] {
+			if name, ok := names[id]; ok {
+				mentions = append(mentions, "@"+name)
+			} else {
+				mentions = append(mentions, "unknown user")
+			}
+		}
+
+		if len(mentions) == 0 {
+			response += fmt.Sprintf("- **%s**: nobody\n", option)
+		} else {
+			response += fmt.Sprintf("- **%s**: %s\n", option, strings.Join(mentions, ", "))
+		}
+	}
+
+	return response, nil
 }
 
 // handlePollEnd ends a poll
@@ -390,6 +933,28 @@ func (c *Client) handlePollEnd(args []string, userID, channelID string) (string,
 	return fmt.Sprintf("Poll has been ended.\n\n%s", results), nil
 }
 
+// handlePollTieBreak lets an owner pick the winner of a tied poll
+func (c *Client) handlePollTieBreak(args []string, userID, channelID string) (string, error) {
+	if len(args) < 2 {
+		return "Usage: `/poll-tiebreak [poll-id] \"Option\"`", nil
+	}
+
+	pollID := args[0]
+	option := strings.Join(args[1:], " ")
+	ctx := context.Background()
+
+	if _, err := c.pollHandler.PickWinner(ctx, pollID, option, userID); err != nil {
+		return "", fmt.Errorf("failed to break tie: %w", err)
+	}
+
+	results, err := c.pollHandler.FormatPollResults(ctx, pollID)
+	if err != nil {
+		return fmt.Sprintf("**%s** has been picked as the winner.", option), nil
+	}
+
+	return results, nil
+}
+
 // handlePollDelete handles poll deletion
 func (c *Client) handlePollDelete(args []string, userID, channelID string) (string, error) {
 	if len(args) < 1 {
@@ -404,43 +969,685 @@ func (c *Client) handlePollDelete(args []string, userID, channelID string) (stri
 		return "", fmt.Errorf("failed to get poll: %w", err)
 	}
 
-	if err := c.pollHandler.DeletePoll(ctx, pollID, userID); err != nil {
+	purgeAt, err := c.pollHandler.DeletePoll(ctx, pollID, userID)
+	if err != nil {
 		return "", fmt.Errorf("failed to delete poll: %w", err)
 	}
 
-	return fmt.Sprintf("Poll **%s: %s** has been deleted.", pollID, poll.Title), nil
+	return fmt.Sprintf("Poll **%s: %s** has been moved to the trash. It will be purged at %s, "+
+		"until then it can be restored with `/poll-restore %s`.", pollID, poll.Title, domain.FormatTime(purgeAt), pollID), nil
 }
 
-// handlePollList prints list of all polls
-func (c *Client) handlePollList(args []string, userID, channelID string) (string, error) {
-	ctx := context.Background()
-	polls, err := c.pollHandler.ListPolls(ctx)
-
+// handlePollTrash lists the user's deleted polls
+func (c *Client) handlePollTrash(args []string, userID, channelID string) (string, error) {
+	trash, err := c.pollHandler.FormatTrash(context.Background(), userID)
 	if err != nil {
-		return "", fmt.Errorf("failed to list polls: %w", err)
+		return "", fmt.Errorf("failed to list trash: %w", err)
 	}
+	return trash, nil
+}
 
-	if len(polls) == 0 {
-		return "No polls found.", nil
+// handlePollRestore brings a deleted poll back from the trash
+func (c *Client) handlePollRestore(args []string, userID, channelID string) (string, error) {
+	if len(args) < 1 {
+		return "Usage: `/poll-restore [poll-id]`", nil
 	}
 
-	response := "### Available Polls\n\n"
+	poll, err := c.pollHandler.RestorePoll(context.Background(), args[0], userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to restore poll: %w", err)
+	}
+
+	return fmt.Sprintf("Poll **%s: %s** has been restored, its status is %s again.", poll.ID, poll.Title, poll.Status), nil
+}
+
+// handlePollList prints list of all polls, with --drafts the user's drafts and scheduled polls
+func (c *Client) handlePollList(args []string, userID, channelID string) (string, error) {
+	_, flags := parseFlags(args, "drafts")
+
+	ctx := context.Background()
+
+	var polls []*domain.Poll
+	var err error
+	if flags["drafts"] == "true" {
+		polls, err = c.pollHandler.ListDrafts(ctx, userID)
+	} else {
+		polls, err = c.pollHandler.ListPolls(ctx)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to list polls: %w", err)
+	}
+
+	if len(polls) == 0 {
+		return "No polls found.", nil
+	}
+
+	response := "### Available Polls\n\n"
+	if flags["drafts"] == "true" {
+		response = "### Your Drafts\n\n"
+	}
 
 	for i, poll := range polls {
-		status := "Active"
-		if !poll.IsActive {
+		var status string
+		switch {
+		case poll.IsDraft():
+			status = "Draft"
+		case poll.IsScheduled():
+			status = "Scheduled, opens at " + domain.FormatTime(poll.OpensAt)
+		case poll.IsOpen():
+			status = "Open"
+		default:
 			status = "Closed"
 		}
 
 		voteCount := len(poll.Votes)
 
 		response += fmt.Sprintf("%d. **%s** (ID: `%s`)\n", i+1, poll.Title, poll.ID)
-		response += fmt.Sprintf("   Status: %s | Votes: %d\n\n", status, voteCount)
+		response += fmt.Sprintf("   Status: %s | Votes: %d", status, voteCount)
+		if poll.IsOpen() && poll.ClosesAt != 0 {
+			response += fmt.Sprintf(" | Closes at: %s", domain.FormatTime(poll.ClosesAt))
+		}
+		response += "\n\n"
+	}
+
+	return response, nil
+}
+
+// handlePollReminders turns reminder DMs off or back on for the user
+func (c *Client) handlePollReminders(args []string, userID, channelID string) (string, error) {
+	if len(args) != 1 || (args[0] != "off" && args[0] != "on") {
+		return "Usage: `/poll-reminders off` to stop reminders about polls you haven't voted in, `/poll-reminders on` to get them again", nil
+	}
+
+	optOut := args[0] == "off"
+	if err := c.pollHandler.SetRemindersOptOut(context.Background(), userID, optOut); err != nil {
+		return "", fmt.Errorf("failed to update reminders: %w", err)
+	}
+
+	if optOut {
+		return "You will no longer get reminders about polls you haven't voted in.", nil
+	}
+	return "You will get reminders about polls you haven't voted in again.", nil
+}
+
+// handlePollAdmin runs the retention policy on demand and searches the archive, for system admins only
+func (c *Client) handlePollAdmin(args []string, userID, channelID string) (string, error) {
+	usage := "Usage: `/poll-admin retention`, `/poll-admin archive search \"query\"` or `/poll-admin archive show [poll-id]`. " +
+		"The archive is searched by poll ID, creator ID, channel ID or a part of the title"
+	if len(args) == 0 {
+		return usage, nil
+	}
+
+	ctx := context.Background()
+
+	switch {
+	case args[0] == "retention" && len(args) == 1:
+		archived, purged, err := c.pollHandler.EnforceRetention(ctx, userID)
+		if err != nil {
+			return "", fmt.Errorf("failed to enforce retention policy: %w", err)
+		}
+		return fmt.Sprintf("Retention policy enforced: %d polls archived, %d archived polls purged.", archived, purged), nil
+	case args[0] == "archive" && len(args) >= 2 && args[1] == "search":
+		result, err := c.pollHandler.SearchArchive(ctx, strings.Join(args[2:], " "), userID)
+		if err != nil {
+			return "", fmt.Errorf("failed to search archive: %w", err)
+		}
+		return result, nil
+	case args[0] == "archive" && len(args) == 3 && args[1] == "show":
+		result, err := c.pollHandler.FormatArchivedPoll(ctx, args[2], userID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get archived poll: %w", err)
+		}
+		return result, nil
+	default:
+		return usage, nil
+	}
+}
+
+// handlePollOwner adds and removes co-owners of a poll and transfers its ownership
+func (c *Client) handlePollOwner(args []string, userID, channelID string) (string, error) {
+	usage := "Usage: `/poll-owner add [poll-id] @user`, `/poll-owner remove [poll-id] @user` or " +
+		"`/poll-owner transfer [poll-id] @user`. Owners can end, edit, reopen and delete the poll"
+	if len(args) != 3 {
+		return usage, nil
+	}
+
+	change := map[string]func(ctx context.Context, pollID, ownerID, userID string) (*domain.Poll, error){
+		"add":      c.pollHandler.AddOwner,
+		"remove":   c.pollHandler.RemoveOwner,
+		"transfer": c.pollHandler.TransferOwnership,
+	}[args[0]]
+	if change == nil {
+		return usage, nil
+	}
+
+	user, err := c.resolveUser(args[2])
+	if err != nil {
+		return "", err
+	}
+
+	poll, err := change(context.Background(), args[1], user.Id, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to change poll owners: %w", err)
+	}
+
+	return fmt.Sprintf("Owners of poll **%s** (ID: `%s`): %s", poll.Title, poll.ID, c.formatUsers(poll.OwnerIDs())), nil
+}
+
+// handlePollRecur creates, lists and stops recurring polls and shows their history
+func (c *Client) handlePollRecur(args []string, userID, channelID string) (string, error) {
+	usage := "Usage: `/poll-recur \"SCHEDULE\" \"Title\" \"Option 1\" \"Option 2\" ... [poll-create flags]`, " +
+		"`/poll-recur list`, `/poll-recur stop [series-id]` or `/poll-recur history [series-id]`.\n" +
+		"SCHEDULE is a cron schedule in UTC like \"0 12 * * fri\" or @daily, @weekly, @monthly"
+	if len(args) == 0 {
+		return usage, nil
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "list":
+		return c.formatSeriesList(ctx, channelID)
+	case "stop":
+		if len(args) < 2 {
+			return usage, nil
+		}
+		series, err := c.pollHandler.StopSeries(ctx, args[1], userID)
+		if err != nil {
+			return "", fmt.Errorf("failed to stop recurring poll: %w", err)
+		}
+		return fmt.Sprintf("Recurring poll **%s** has been stopped, no new polls will be opened. "+
+			"Its history is still available with `/poll-recur history %s`", series.Title, series.ID), nil
+	case "history":
+		if len(args) < 2 {
+			return usage, nil
+		}
+		history, err := c.pollHandler.FormatSeriesHistory(ctx, args[1])
+		if err != nil {
+			return "", fmt.Errorf("failed to get history: %w", err)
+		}
+		return history, nil
+	}
+
+	args, flags := parseFlags(args, "anonymous", "public", "lock-votes")
+	if len(args) < 4 {
+		return usage, nil
+	}
+
+	schedule, title, options := args[0], args[1], args[2:]
+
+	settings, closesAt, message := c.parsePollSettings(flags, channelID)
+	if message != "" {
+		return message, nil
+	}
+	if closesAt != 0 {
+		return "Error: recurring polls can't have a fixed closing time, use `--closes-in` instead.", nil
+	}
+
+	series, err := c.pollHandler.CreateSeries(ctx, schedule, title, options, userID, channelID, settings)
+	if err != nil {
+		return "", fmt.Errorf("failed to create recurring poll: %w", err)
+	}
+
+	return fmt.Sprintf("### Recurring Poll Created: %s\n\n**Series ID:** %s\n\n**Schedule:** `%s` (UTC), the first poll opens at %s. "+
+		"Every new poll closes the previous one and posts its results here\n\n"+
+		"**Options:**\n%s\nTo see how results shift: `/poll-recur history %s`\nTo stop it: `/poll-recur stop %s`",
+		series.Title, series.ID, series.Schedule, domain.FormatTime(series.NextRunAt),
+		formatOptions(series.Options), series.ID, series.ID), nil
+}
+
+// formatSeriesList lists the recurring polls of the channel
+func (c *Client) formatSeriesList(ctx context.Context, channelID string) (string, error) {
+	series, err := c.pollHandler.ListSeries(ctx, channelID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list recurring polls: %w", err)
+	}
+
+	if len(series) == 0 {
+		return "No recurring polls in this channel.", nil
+	}
+
+	response := "### Recurring Polls\n\n"
+	for i, item := range series {
+		status := "next poll at " + domain.FormatTime(item.NextRunAt)
+		if item.IsStopped() {
+			status = "stopped"
+		}
+
+		response += fmt.Sprintf("%d. **%s** (ID: `%s`)\n", i+1, item.Title, item.ID)
+		response += fmt.Sprintf("   Schedule: `%s` | Polls: %d | %s\n\n", item.Schedule, len(item.PollIDs), status)
 	}
 
 	return response, nil
 }
 
+// handlePollTemplate saves, uses, lists and deletes the team's poll templates
+func (c *Client) handlePollTemplate(args []string, userID, channelID string) (string, error) {
+	usage := "Usage: `/poll-template save [name] \"Title\" \"Option 1\" \"Option 2\" ... [--private] [poll-create flags]`, " +
+		"`/poll-template use [name]`, `/poll-template list` or `/poll-template delete [name]`"
+	if len(args) == 0 {
+		return usage, nil
+	}
+
+	teamID, err := c.teamOf(channelID)
+	if err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "save":
+		args, flags := parseFlags(args[1:], "anonymous", "public", "lock-votes", "private")
+		if len(args) < 4 {
+			return usage, nil
+		}
+
+		settings, closesAt, message := c.parsePollSettings(flags, channelID)
+		if message != "" {
+			return message, nil
+		}
+		if closesAt != 0 {
+			return "Error: templates can't have a fixed closing time, use `--closes-in` instead.", nil
+		}
+
+		template, err := c.pollHandler.SaveTemplate(ctx, teamID, args[0], args[1], args[2:], settings, flags["private"] == "true", userID)
+		if err != nil {
+			return "", fmt.Errorf("failed to save template: %w", err)
+		}
+
+		scope := "shared with the team"
+		if template.IsPrivate() {
+			scope = "private, only you can use it"
+		}
+		return fmt.Sprintf("Template **%s** (%s) has been saved. Create a poll from it with `/poll-template use %s`",
+			template.Name, scope, template.Name), nil
+	case "use":
+		if len(args) < 2 {
+			return usage, nil
+		}
+		return c.createFromTemplate(ctx, teamID, args[1], userID, channelID)
+	case "list":
+		return c.formatTemplates(ctx, teamID, userID)
+	case "delete":
+		if len(args) < 2 {
+			return usage, nil
+		}
+		template, err := c.pollHandler.DeleteTemplate(ctx, teamID, args[1], userID)
+		if err != nil {
+			return "", fmt.Errorf("failed to delete template: %w", err)
+		}
+		return fmt.Sprintf("Template **%s** has been deleted.", template.Name), nil
+	default:
+		return usage, nil
+	}
+}
+
+// createFromTemplate creates a poll in the channel from the template. A quorum given as
+// a percentage is resolved against the members of this channel
+func (c *Client) createFromTemplate(ctx context.Context, teamID, name, userID, channelID string) (string, error) {
+	template, err := c.pollHandler.GetTemplate(ctx, teamID, name, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get template: %w", err)
+	}
+
+	settings := template.Settings
+	if settings.QuorumPercent > 0 {
+		quorum, _, err := c.resolveQuorum(fmt.Sprintf("%d%%", settings.QuorumPercent), channelID)
+		if err != nil {
+			return fmt.Sprintf("Error: %v", err), nil
+		}
+		settings.Quorum = quorum
+	}
+
+	poll, err := c.pollHandler.CreatePoll(ctx, template.Title, template.Options, userID, channelID, 0, settings)
+	if err != nil {
+		return "", fmt.Errorf("failed to create poll: %w", err)
+	}
+
+	return fmt.Sprintf("### Poll Created: %s\n\n**ID:** %s\n\n_From template %s_\n\n%s%s",
+		poll.Title, poll.ID, template.Name, describePoll(poll), voteHint(poll)), nil
+}
+
+// formatTemplates lists the templates the user can use
+func (c *Client) formatTemplates(ctx context.Context, teamID, userID string) (string, error) {
+	templates, err := c.pollHandler.ListTemplates(ctx, teamID, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	if len(templates) == 0 {
+		return "No templates yet. Save one with `/poll-template save [name] \"Title\" \"Option 1\" \"Option 2\" ...`", nil
+	}
+
+	response := "### Poll Templates\n\n"
+	for _, template := range templates {
+		scope := ""
+		if template.IsPrivate() {
+			scope = " (private)"
+		}
+		response += fmt.Sprintf("- **%s**%s: %s, %d options, %s\n", template.Name, scope, template.Title,
+			len(template.Options), template.Settings.Type)
+	}
+	response += "\nCreate a poll from a template with `/poll-template use [name]`"
+
+	return response, nil
+}
+
+// handlePollClone opens a copy of a poll in this or another channel
+func (c *Client) handlePollClone(args []string, userID, channelID string) (string, error) {
+	args, flags := parseFlags(args)
+
+	if len(args) < 1 {
+		return "Usage: `/poll-clone [poll-id] [--channel ~channel]`", nil
+	}
+
+	ctx := context.Background()
+
+	targetID, targetName := channelID, ""
+	if value, ok := flags["channel"]; ok {
+		channel, err := c.resolveChannel(value, channelID, userID)
+		if err != nil {
+			return fmt.Sprintf("Error: %v", err), nil
+		}
+		targetID, targetName = channel.Id, channel.Name
+	}
+
+	source, err := c.pollHandler.GetPoll(ctx, args[0])
+	if err != nil {
+		return "", fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	// a quorum given as a percentage is resolved against the members of the target channel
+	quorum := 0
+	if source.Settings.QuorumPercent > 0 {
+		quorum, _, err = c.resolveQuorum(fmt.Sprintf("%d%%", source.Settings.QuorumPercent), targetID)
+		if err != nil {
+			return fmt.Sprintf("Error: %v", err), nil
+		}
+	}
+
+	poll, err := c.pollHandler.ClonePoll(ctx, source.ID, targetID, quorum, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to clone poll: %w", err)
+	}
+
+	announcement := fmt.Sprintf("### Poll Created: %s\n\n**ID:** %s\n\n_Re-run of poll `%s`, compare the results with `/poll-results %s --compare`_\n\n%s%s",
+		poll.Title, poll.ID, source.ID, poll.ID, describePoll(poll), voteHint(poll))
+
+	if targetID == channelID {
+		return announcement, nil
+	}
+
+	if err := c.PostMessage(targetID, announcement); err != nil {
+		return "", fmt.Errorf("poll %s was created but could not be announced: %w", poll.ID, err)
+	}
+
+	return fmt.Sprintf("Poll **%s** has been cloned to ~%s as `%s`. Compare the results with `/poll-results %s --compare`",
+		poll.Title, targetName, poll.ID, poll.ID), nil
+}
+
+// handlePollReopen reopens a closed poll
+func (c *Client) handlePollReopen(args []string, userID, channelID string) (string, error) {
+	args, flags := parseFlags(args)
+
+	if len(args) < 1 {
+		return "Usage: `/poll-reopen [poll-id] [--for 24h]`", nil
+	}
+
+	var duration uint64
+	if value, ok := flags["for"]; ok {
+		d, err := parseDuration(value)
+		if err != nil {
+			return fmt.Sprintf("Error: %v", err), nil
+		}
+		duration = uint64(d / time.Second)
+	}
+
+	poll, err := c.pollHandler.ReopenPoll(context.Background(), args[0], duration, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to reopen poll: %w", err)
+	}
+
+	closing := "It stays open until it is ended with `/poll-end " + poll.ID + "`."
+	if poll.ClosesAt != 0 {
+		closing = "It closes at " + domain.FormatTime(poll.ClosesAt) + "."
+	}
+
+	return fmt.Sprintf("Poll **%s** has been reopened, %d vote(s) cast before closing were kept. %s\n%s",
+		poll.Title, poll.VoterCount(), closing, voteHint(poll)), nil
+}
+
+// handlePollExtend moves the closing time of a poll later
+func (c *Client) handlePollExtend(args []string, userID, channelID string) (string, error) {
+	if len(args) < 2 {
+		return "Usage: `/poll-extend [poll-id] [duration]`, e.g. `/poll-extend [poll-id] 2h`", nil
+	}
+
+	d, err := parseDuration(args[1])
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err), nil
+	}
+
+	poll, err := c.pollHandler.ExtendPoll(context.Background(), args[0], uint64(d/time.Second), userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to extend poll: %w", err)
+	}
+
+	return fmt.Sprintf("Poll **%s** has been extended by %s, it now closes at %s.",
+		poll.Title, formatDuration(d), domain.FormatTime(poll.ClosesAt)), nil
+}
+
+// handlePollEdit changes the title and options of a poll or shows its edit history
+func (c *Client) handlePollEdit(args []string, userID, channelID string) (string, error) {
+	if len(args) == 2 && args[1] == "--history" {
+		history, err := c.pollHandler.FormatEditHistory(context.Background(), args[0])
+		if err != nil {
+			return "", fmt.Errorf("failed to get edit history: %w", err)
+		}
+		return history, nil
+	}
+
+	pollID, edit, err := parseEditArgs(args)
+	if err != nil {
+		return fmt.Sprintf("Error: %v\n\nUsage: `/poll-edit [poll-id] [--title \"Title\"] [--add \"Option\"] [--remove \"Option\"] [--rename \"Old=New\"]`, "+
+			"--add, --remove and --rename can be repeated, or `/poll-edit [poll-id] --history`", err), nil
+	}
+
+	poll, err := c.pollHandler.EditPoll(context.Background(), pollID, edit, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to edit poll: %w", err)
+	}
+
+	heading := "Poll Updated"
+	if poll.IsDraft() || poll.IsScheduled() {
+		heading = "Draft Updated"
+	}
+	response := fmt.Sprintf("### %s: %s\n\n**ID:** %s\n\n%s", heading, poll.Title, poll.ID, describePoll(poll))
+
+	if edit := poll.Edits[len(poll.Edits)-1]; edit.ClearedVotes > 0 {
+		response += fmt.Sprintf("\n\n%d votes for the removed options were cleared, their voters have been notified.", edit.ClearedVotes)
+	}
+	return response, nil
+}
+
+// parseEditArgs parses the poll ID and the repeatable edit flags of /poll-edit
+func parseEditArgs(args []string) (string, domain.PollEdit, error) {
+	var pollID string
+	edit := domain.PollEdit{Rename: make(map[string]string)}
+
+	for i := 0; i < len(args); i++ {
+		name, ok := strings.CutPrefix(args[i], "--")
+		if !ok {
+			if pollID != "" {
+				return "", edit, fmt.Errorf("unexpected argument %q", args[i])
+			}
+			pollID = args[i]
+			continue
+		}
+
+		if i+1 >= len(args) {
+			return "", edit, fmt.Errorf("`--%s` needs a value", name)
+		}
+		value := args[i+1]
+		i++
+
+		switch name {
+		case "title":
+			edit.Title = strings.TrimSpace(value)
+		case "add":
+			edit.Add = append(edit.Add, value)
+		case "remove":
+			edit.Remove = append(edit.Remove, value)
+		case "rename":
+			old, renamed, found := strings.Cut(value, "=")
+			if !found || strings.TrimSpace(renamed) == "" {
+				return "", edit, fmt.Errorf("`--rename` takes \"Old=New\", got %q", value)
+			}
+			edit.Rename[strings.TrimSpace(old)] = renamed
+		default:
+			return "", edit, fmt.Errorf("unknown flag `--%s`", name)
+		}
+	}
+
+	if pollID == "" {
+		return "", edit, fmt.Errorf("poll ID is required")
+	}
+	if edit.Title == "" && len(edit.Add) == 0 && len(edit.Remove) == 0 && len(edit.Rename) == 0 {
+		return "", edit, fmt.Errorf("nothing to change")
+	}
+
+	return pollID, edit, nil
+}
+
+// handlePollPublish opens a draft or scheduled poll right away
+func (c *Client) handlePollPublish(args []string, userID, channelID string) (string, error) {
+	if len(args) < 1 {
+		return "Usage: `/poll-publish [poll-id]`", nil
+	}
+
+	poll, err := c.pollHandler.PublishPoll(context.Background(), args[0], userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to publish poll: %w", err)
+	}
+
+	return fmt.Sprintf("### Poll Opened: %s\n\n**ID:** %s\n\n%s%s", poll.Title, poll.ID, describePoll(poll), voteHint(poll)), nil
+}
+
+// handlePollSchedule schedules a draft to open later
+func (c *Client) handlePollSchedule(args []string, userID, channelID string) (string, error) {
+	if len(args) < 2 {
+		return "Usage: `/poll-schedule [poll-id] [TIME]`, TIME is a duration like 2h or 1d, or a UTC time like \"2025-06-01 09:00\"", nil
+	}
+
+	opensAt, err := parseTime(strings.Join(args[1:], " "), time.Now())
+	if err != nil {
+		return fmt.Sprintf("Error: %v", err), nil
+	}
+
+	poll, err := c.pollHandler.SchedulePoll(context.Background(), args[0], uint64(opensAt.Unix()), userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to schedule poll: %w", err)
+	}
+
+	return fmt.Sprintf("Poll **%s** (ID: `%s`) will open at %s and will be announced in the channel it was created in.\n"+
+		"Until then you can still change it with `/poll-edit %s` or open it right away with `/poll-publish %s`",
+		poll.Title, poll.ID, domain.FormatTime(poll.OpensAt), poll.ID, poll.ID), nil
+}
+
+// handlePollSuggest proposes a new option, without an option it lists pending suggestions
+func (c *Client) handlePollSuggest(args []string, userID, channelID string) (string, error) {
+	if len(args) < 1 {
+		return "Usage: `/poll-suggest [poll-id] \"Option\"` or `/poll-suggest [poll-id]` to list pending suggestions", nil
+	}
+
+	pollID := args[0]
+	ctx := context.Background()
+
+	if len(args) == 1 {
+		poll, err := c.pollHandler.GetPoll(ctx, pollID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get poll: %w", err)
+		}
+		return formatSuggestions(poll), nil
+	}
+
+	option := strings.Join(args[1:], " ")
+
+	poll, err := c.pollHandler.SuggestOption(ctx, pollID, option, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to suggest option: %w", err)
+	}
+
+	number := len(poll.Suggestions)
+	return fmt.Sprintf("Option **%s** has been suggested for poll **%s** and is waiting for the owners' approval.\n\n"+
+		"An owner can add it with `/poll-approve %s %d` or reject it with `/poll-reject %s %d`",
+		strings.TrimSpace(option), poll.Title, poll.ID, number, poll.ID, number), nil
+}
+
+// handlePollApprove adds a suggested option to the poll
+func (c *Client) handlePollApprove(args []string, userID, channelID string) (string, error) {
+	pollID, number, usage := parseSuggestionArgs(args, "poll-approve")
+	if usage != "" {
+		return usage, nil
+	}
+
+	suggestion, err := c.pollHandler.ApproveSuggestion(context.Background(), pollID, number, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to approve suggestion: %w", err)
+	}
+
+	return fmt.Sprintf("Option **%s** has been added to the poll. Vote for it with `/poll-vote %s \"%s\"`",
+		suggestion.Option, pollID, suggestion.Option), nil
+}
+
+// handlePollReject rejects a suggested option
+func (c *Client) handlePollReject(args []string, userID, channelID string) (string, error) {
+	pollID, number, usage := parseSuggestionArgs(args, "poll-reject")
+	if usage != "" {
+		return usage, nil
+	}
+
+	suggestion, err := c.pollHandler.RejectSuggestion(context.Background(), pollID, number, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to reject suggestion: %w", err)
+	}
+
+	return fmt.Sprintf("Suggested option **%s** has been rejected.", suggestion.Option), nil
+}
+
+// parseSuggestionArgs parses "poll-id suggestion-number" arguments, returning usage text if they are invalid
+func parseSuggestionArgs(args []string, command string) (string, int, string) {
+	usage := fmt.Sprintf("Usage: `/%s [poll-id] [suggestion-number]`, see `/poll-suggest [poll-id]` for the numbers", command)
+	if len(args) < 2 {
+		return "", 0, usage
+	}
+
+	number, err := strconv.Atoi(args[1])
+	if err != nil || number < 1 {
+		return "", 0, usage
+	}
+
+	return args[0], number, ""
+}
+
+// formatSuggestions lists the poll's pending suggestions with their numbers
+func formatSuggestions(poll *domain.Poll) string {
+	if len(poll.Suggestions) == 0 {
+		return fmt.Sprintf("Poll **%s** has no pending suggestions.", poll.Title)
+	}
+
+	response := fmt.Sprintf("### Pending suggestions for %s\n\n", poll.Title)
+	for i, suggestion := range poll.Suggestions {
+		response += fmt.Sprintf("%d. **%s**\n", i+1, suggestion.Option)
+	}
+	response += fmt.Sprintf("\nAn owner can add an option with `/poll-approve %s N` or reject it with `/poll-reject %s N`",
+		poll.ID, poll.ID)
+
+	return response
+}
+
 // PostMessage posts message to the channel
 func (c *Client) PostMessage(channelID, message string) error {
 	post := &model.Post{
@@ -0,0 +1,100 @@
+package mattermost
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseFlags separates "--name value" flags from positional arguments.
+// Flags listed in boolFlags take no value and are set to "true"
+func parseFlags(args []string, boolFlags ...string) ([]string, map[string]string) {
+	isBool := make(map[string]bool, len(boolFlags))
+	for _, name := range boolFlags {
+		isBool[name] = true
+	}
+
+	var positional []string
+	flags := make(map[string]string)
+
+	for i := 0; i < len(args); i++ {
+		name, ok := strings.CutPrefix(args[i], "--")
+		if !ok || name == "" {
+			positional = append(positional, args[i])
+			continue
+		}
+
+		if key, value, found := strings.Cut(name, "="); found {
+			flags[key] = value
+			continue
+		}
+
+		if isBool[name] || i+1 >= len(args) {
+			flags[name] = "true"
+			continue
+		}
+
+		flags[name] = args[i+1]
+		i++
+	}
+
+	return positional, flags
+}
+
+// timeLayouts are the absolute time formats accepted by time flags, times without a zone are UTC
+var timeLayouts = []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"}
+
+// parseDuration parses a positive duration such as "90m", "2h" or "3d"
+func parseDuration(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: use a duration like 90m, 2h or 3d", value)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("duration %q must be positive", value)
+	}
+	return d, nil
+}
+
+// formatDuration formats a duration without zero trailing units, like "24h" or "1h30m"
+func formatDuration(d time.Duration) string {
+	text := d.String()
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
+
+// parseTime parses an absolute time or a duration from now such as "90m", "2h" or "3d"
+func parseTime(value string, now time.Time) (time.Time, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return now.AddDate(0, 0, n), nil
+		}
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("duration %q must be positive", value)
+		}
+		return now.Add(d), nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q: use a duration like 2h or 3d, or a UTC time like \"2006-01-02 15:04\"", value)
+}
@@ -0,0 +1,117 @@
+package mattermost
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// membersPageSize is the number of channel members fetched per request
+const membersPageSize = 200
+
+// ChannelMembers returns the IDs of the channel's members except the bot itself
+func (c *Client) ChannelMembers(channelID string) ([]string, error) {
+	var userIDs []string
+
+	for page := 0; ; page++ {
+		members, _, err := c.client.GetChannelMembers(channelID, page, membersPageSize, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get channel members: %w", err)
+		}
+
+		for _, member := range members {
+			if member.UserId != c.botUser.Id {
+				userIDs = append(userIDs, member.UserId)
+			}
+		}
+
+		if len(members) < membersPageSize {
+			return userIDs, nil
+		}
+	}
+}
+
+// teamOf returns the ID of the team the channel belongs to
+func (c *Client) teamOf(channelID string) (string, error) {
+	channel, _, err := c.client.GetChannel(channelID, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get channel: %w", err)
+	}
+
+	if channel.TeamId == "" {
+		return "", fmt.Errorf("this command works in team channels only")
+	}
+	return channel.TeamId, nil
+}
+
+// resolveChannel resolves a channel given by ID or by name in the team of the current channel.
+// The user must be a member of the channel
+func (c *Client) resolveChannel(value, currentChannelID, userID string) (*model.Channel, error) {
+	name := strings.TrimPrefix(value, "~")
+
+	channel, _, err := c.client.GetChannel(name, "")
+	if err != nil {
+		teamID, teamErr := c.teamOf(currentChannelID)
+		if teamErr != nil {
+			return nil, teamErr
+		}
+		channel, _, err = c.client.GetChannelByName(name, teamID, "")
+		if err != nil {
+			return nil, fmt.Errorf("channel %s not found", value)
+		}
+	}
+
+	if _, _, err := c.client.GetChannelMember(channel.Id, userID, ""); err != nil {
+		return nil, fmt.Errorf("you are not a member of channel %s", value)
+	}
+	return channel, nil
+}
+
+// resolveUser resolves a user given as @username or by ID
+func (c *Client) resolveUser(value string) (*model.User, error) {
+	user, _, err := c.client.GetUserByUsername(strings.TrimPrefix(value, "@"), "")
+	if err != nil {
+		if user, _, err = c.client.GetUser(value, ""); err != nil {
+			return nil, fmt.Errorf("user %s not found", value)
+		}
+	}
+	return user, nil
+}
+
+// formatUsers formats the users as @-mentions, users that can't be looked up are shown by ID
+func (c *Client) formatUsers(userIDs []string) string {
+	usernames, err := c.usernames(userIDs)
+	if err != nil {
+		slog.Warn("Failed to resolve usernames", "error", err)
+	}
+
+	names := make([]string, len(userIDs))
+	for i, userID := range userIDs {
+		names[i] = userID
+		if username, ok := usernames[userID]; ok {
+			names[i] = "@" + username
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// IsSystemAdmin reports whether the user has the system_admin role
+func (c *Client) IsSystemAdmin(userID string) (bool, error) {
+	user, _, err := c.client.GetUser(userID, "")
+	if err != nil {
+		return false, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user.IsSystemAdmin(), nil
+}
+
+// SendDirectMessage sends a direct message from the bot to the user
+func (c *Client) SendDirectMessage(userID, message string) error {
+	channel, _, err := c.client.CreateDirectChannel(c.botUser.Id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to open direct channel: %w", err)
+	}
+
+	return c.PostMessage(channel.Id, message)
+}
@@ -0,0 +1,41 @@
+package mattermost
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// resolveQuorum parses the --quorum flag, either an absolute number of voters or a
+// percentage of the channel's members. Percentages are resolved against the members at creation time
+func (c *Client) resolveQuorum(value, channelID string) (int, int, error) {
+	raw, isPercent := strings.CutSuffix(value, "%")
+
+	number, err := strconv.Atoi(raw)
+	if err != nil || number < 1 {
+		return 0, 0, fmt.Errorf("quorum must be a positive number of voters or a percentage like 30%%")
+	}
+
+	if !isPercent {
+		return number, 0, nil
+	}
+
+	if number > 100 {
+		return 0, 0, errors.New("quorum percentage must be between 1 and 100")
+	}
+
+	stats, _, err := c.client.GetChannelStats(channelID, "")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get channel members: %w", err)
+	}
+
+	// the bot itself is a member of the channel but never votes
+	members := int(stats.MemberCount) - 1
+	quorum := (members*number + 99) / 100
+	if quorum < 1 {
+		quorum = 1
+	}
+
+	return quorum, number, nil
+}
@@ -0,0 +1,82 @@
+package mattermost
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// UserCacheTTL is how long resolved usernames are kept in the cache
+const UserCacheTTL = 10 * time.Minute
+
+// cachedUser is a cached username with its expiration time
+type cachedUser struct {
+	username  string
+	expiresAt time.Time
+}
+
+// userCache caches usernames by user ID
+type userCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedUser
+}
+
+// newUserCache creates an empty user cache
+func newUserCache() *userCache {
+	return &userCache{
+		entries: make(map[string]cachedUser),
+	}
+}
+
+// get returns the cached username if it has not expired
+func (uc *userCache) get(userID string) (string, bool) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	entry, ok := uc.entries[userID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return "", false
+	}
+	return entry.username, true
+}
+
+// put stores the username in the cache
+func (uc *userCache) put(userID, username string) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.entries[userID] = cachedUser{
+		username:  username,
+		expiresAt: time.Now().Add(UserCacheTTL),
+	}
+}
+
+// usernames resolves user IDs to usernames, users missing in the cache are fetched in one request
+func (c *Client) usernames(userIDs []string) (map[string]string, error) {
+	result := make(map[string]string, len(userIDs))
+	var missing []string
+
+	for _, userID := range userIDs {
+		if username, ok := c.users.get(userID); ok {
+			result[userID] = username
+		} else {
+			missing = append(missing, userID)
+		}
+	}
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	users, _, err := c.client.GetUsersByIds(missing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	for _, user := range users {
+		c.users.put(user.Id, user.Username)
+		result[user.Id] = user.Username
+	}
+
+	return result, nil
+}
@@ -0,0 +1,131 @@
+package mattermost
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// usersPerPage is the page size used when listing users
+const usersPerPage = 200
+
+// parseWeightTable parses "name=weight,name=weight" pairs
+func parseWeightTable(value string) (map[string]float64, error) {
+	table := make(map[string]float64)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, rawWeight, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("expected name=weight, got %q", pair)
+		}
+
+		weight, err := strconv.ParseFloat(strings.TrimSpace(rawWeight), 64)
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("weight for %q must be a positive number", name)
+		}
+
+		table[strings.TrimPrefix(strings.TrimSpace(name), "@")] = weight
+	}
+	return table, nil
+}
+
+// resolveWeights builds a user_id -> weight table from the --weight-group and --weights flags.
+// Group weights are resolved from the group members at creation time, explicit user weights take precedence
+func (c *Client) resolveWeights(flags map[string]string) (map[string]float64, error) {
+	weights := make(map[string]float64)
+
+	if value, ok := flags["weight-group"]; ok {
+		groups, err := parseWeightTable(value)
+		if err != nil {
+			return nil, err
+		}
+
+		for name, weight := range groups {
+			members, err := c.groupMembers(name)
+			if err != nil {
+				return nil, err
+			}
+			for _, userID := range members {
+				weights[userID] = weight
+			}
+		}
+	}
+
+	if value, ok := flags["weights"]; ok {
+		users, err := parseWeightTable(value)
+		if err != nil {
+			return nil, err
+		}
+
+		usernames := make([]string, 0, len(users))
+		for username := range users {
+			usernames = append(usernames, username)
+		}
+
+		found, _, err := c.client.GetUsersByUsernames(usernames)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get users: %w", err)
+		}
+		for _, user := range found {
+			weights[user.Id] = users[user.Username]
+			delete(users, user.Username)
+		}
+
+		if len(users) > 0 {
+			missing := make([]string, 0, len(users))
+			for username := range users {
+				missing = append(missing, "@"+username)
+			}
+			return nil, fmt.Errorf("users not found: %s", strings.Join(missing, ", "))
+		}
+	}
+
+	if len(weights) == 0 {
+		return nil, nil
+	}
+	return weights, nil
+}
+
+// groupMembers returns the IDs of the members of a Mattermost group
+func (c *Client) groupMembers(name string) ([]string, error) {
+	groups, _, err := c.client.GetGroups(model.GroupSearchOpts{
+		Q:        name,
+		PageOpts: &model.PageOpts{Page: 0, PerPage: usersPerPage},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search groups: %w", err)
+	}
+
+	var group *model.Group
+	for _, g := range groups {
+		if g.Name != nil && *g.Name == name {
+			group = g
+			break
+		}
+	}
+	if group == nil {
+		return nil, fmt.Errorf("group %q not found", name)
+	}
+
+	var members []string
+	for page := 0; ; page++ {
+		users, _, err := c.client.GetUsersInGroup(group.Id, page, usersPerPage, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get members of group %q: %w", name, err)
+		}
+		for _, user := range users {
+			members = append(members, user.Id)
+		}
+		if len(users) < usersPerPage {
+			break
+		}
+	}
+
+	return members, nil
+}
@@ -0,0 +1,8 @@
+package model
+
+// ArchivedPoll is a closed poll moved out of the polls space by the retention policy
+type ArchivedPoll struct {
+	Poll       *Poll  `json:"poll"`
+	ClosedAt   uint64 `json:"closed_at"`   // unix time the poll was closed, retention periods count from it
+	ArchivedAt uint64 `json:"archived_at"` // unix time the poll was archived
+}
@@ -1,11 +1,390 @@
 package model
 
+import "time"
+
+// poll types
+const (
+	PollTypeSingle    = "single"
+	PollTypeRanked    = "ranked"
+	PollTypeApproval  = "approval"
+	PollTypeScore     = "score"
+	PollTypeSTV       = "stv"
+	PollTypeQuadratic = "quadratic"
+)
+
+// tally methods
+const (
+	MethodIRV       = "irv"
+	MethodBorda     = "borda"
+	MethodSchulze   = "schulze"
+	MethodSTAR      = "star"      // score polls only
+	MethodSTV       = "stv"       // stv polls only
+	MethodQuadratic = "quadratic" // quadratic polls only
+)
+
+// poll lifecycle states: draft -> scheduled -> open -> closed, drafts may also be published directly
+const (
+	StatusDraft     = "draft"     // being prepared by the owners, hidden and closed to votes
+	StatusScheduled = "scheduled" // waiting for its opening time
+	StatusOpen      = "open"
+	StatusClosed    = "closed"
+	StatusTrashed   = "trashed" // deleted, kept for a while so it can be restored
+)
+
+// poll lifecycle events kept in the poll's history
+const (
+	EventClosed   = "closed"
+	EventReopened = "reopened"
+	EventExtended = "extended"
+)
+
+// poll visibility
+const (
+	VisibilityConfidential = "confidential" // voters are stored with their ballots but never shown
+	VisibilityAnonymous    = "anonymous"    // ballots are stored under pseudonymous vote keys only
+	VisibilityPublic       = "public"       // anyone can see who voted for what
+)
+
+// pass thresholds of decision polls, a threshold can also be given as a percentage like "60%"
+const (
+	ThresholdMajority  = "majority"   // more than half of the votes
+	ThresholdTwoThirds = "two-thirds" // at least two thirds of the votes
+)
+
+// outcomes of decision polls
+const (
+	OutcomePassed   = "PASSED"
+	OutcomeFailed   = "FAILED"
+	OutcomeNoQuorum = "NO QUORUM"
+)
+
+// vote change policies
+const (
+	VotePolicyChange = "change" // votes may be changed and retracted while the poll is open
+	VotePolicyLock   = "lock"   // the first vote is final
+	VotePolicyWindow = "window" // votes may be changed and retracted until the cutoff time
+)
+
+// tie-break policies
+const (
+	TieBreakCreator  = "creator"  // an owner picks the winner after the poll is closed
+	TieBreakRandom   = "random"   // seeded random draw, the seed is recorded for audit
+	TieBreakEarliest = "earliest" // the option that reached its final count first wins
+	TieBreakRunoff   = "runoff"   // a runoff poll between the tied options is created
+)
+
+// MaxReasonLength is the maximum length of a ballot's reason in characters
+const MaxReasonLength = 280
+
+// MaxReminderOffset is how long before the closing time a reminder can be sent at most
+const MaxReminderOffset = 7 * 24 * time.Hour
+
+// DefaultCredits is the voice credit budget of quadratic polls
+const DefaultCredits = 100
+
+// MaxCredits is the largest voice credit budget, it keeps the cost of a ballot far from overflowing
+const MaxCredits = 1000000
+
+// score range for score polls
+const (
+	MinScore = 0
+	MaxScore = 5
+)
+
 type Poll struct {
-	ID        string            `json:"id"`
-	Title     string            `json:"title"`
-	Options   []string          `json:"options"`
-	CreatedBy string            `json:"created_by"`
-	CreatedAt uint64             `json:"created_at"`
-	IsActive  bool              `json:"is_active"`
-	Votes     map[string]string `json:"votes"` // map[user_id] = option_index
+	ID          string            `json:"id"`
+	Title       string            `json:"title"`
+	Options     []string          `json:"options"`
+	CreatedBy   string            `json:"created_by"`
+	CreatedAt   uint64            `json:"created_at"`
+	Status      string            `json:"status"`
+	Votes       map[string]Ballot `json:"votes"` // map[user_id] = ballot, map[vote_key] = ballot for anonymous polls
+	Settings    PollSettings      `json:"settings"`
+	Suggestions []Suggestion      `json:"suggestions"`  // write-in options awaiting the owners' decision
+	TieBreak    *TieBreak         `json:"tie_break"`    // how a tie for the win was resolved when the poll closed
+	ChannelID   string            `json:"channel_id"`   // channel the poll was created in
+	ClosesAt    uint64            `json:"closes_at"`    // unix time the poll closes automatically, 0 means never
+	OpensAt     uint64            `json:"opens_at"`     // unix time the poll opened or is scheduled to open
+	Reminded    []uint64          `json:"reminded"`     // reminder offsets already sent, in seconds before the closing time
+	SeriesID    string            `json:"series_id"`    // recurring series the poll was opened by, if any
+	ClonedFrom  string            `json:"cloned_from"`  // poll this poll was cloned from, if any
+	Events      []PollEvent       `json:"events"`       // close, reopen and extend history, oldest first
+	Edits       []EditRecord      `json:"edits"`        // title and option changes, oldest first
+	DeletedAt   uint64            `json:"deleted_at"`   // unix time the poll was moved to the trash
+	DeletedFrom string            `json:"deleted_from"` // status the poll had before it was moved to the trash
+	Owners      []string          `json:"owners"`       // users who manage the poll, empty means the creator alone
+}
+
+// PollSettings contains voting rules chosen at poll creation
+type PollSettings struct {
+	Type          string             `json:"type"`
+	Method        string             `json:"method"`
+	MaxChoices    int                `json:"max_choices"` // approval polls only, 0 means no limit
+	Seats         int                `json:"seats"`       // stv polls only
+	Credits       int                `json:"credits"`     // quadratic polls only
+	Weights       map[string]float64 `json:"weights"`     // map[user_id] = weight, other voters weigh 1
+	Visibility    string             `json:"visibility"`
+	Quorum        int                `json:"quorum"`         // minimum number of voters, 0 means no quorum
+	QuorumPercent int                `json:"quorum_percent"` // share of channel members the quorum was derived from, if any
+	Threshold     string             `json:"threshold"`      // share of votes the leading option needs to pass
+	TieBreak      string             `json:"tie_break"`      // tie-break policy, empty means ties are reported as is
+	VotePolicy    string             `json:"vote_policy"`
+	Duration      uint64             `json:"duration"`     // seconds the poll stays open, the closing time is set when it opens
+	ChangeUntil   uint64             `json:"change_until"` // unix time of the cutoff, window policy only
+	Reminders     []uint64           `json:"reminders"`    // seconds before the closing time to remind members who haven't voted
+}
+
+// Suggestion is an option proposed by a voter
+type Suggestion struct {
+	Option      string `json:"option"`
+	SuggestedBy string `json:"suggested_by"`
+	SuggestedAt uint64 `json:"suggested_at"`
+}
+
+// TieBreak records how a tie for the win was resolved
+type TieBreak struct {
+	Policy   string   `json:"policy"`
+	Tied     []string `json:"tied"`
+	Winner   string   `json:"winner"`    // empty until an owner decides, always empty for runoffs
+	Seed     int64    `json:"seed"`      // seed of the random draw, if one was made
+	RunoffID string   `json:"runoff_id"` // runoff only
+}
+
+// PollEvent records a change of the poll's lifecycle
+type PollEvent struct {
+	Type  string `json:"type"`
+	At    uint64 `json:"at"`    // unix time of the event
+	By    string `json:"by"`    // user ID, empty for events caused by the bot
+	Until uint64 `json:"until"` // closing time set by the event, 0 means none
+}
+
+// PollEdit describes requested changes to a poll's title and options
+type PollEdit struct {
+	Title  string            // new title, empty keeps the current one
+	Add    []string          // options to add
+	Remove []string          // options to remove
+	Rename map[string]string // map[old option] = new option
+}
+
+// EditRecord records a change of the poll's title and options
+type EditRecord struct {
+	At           uint64            `json:"at"` // unix time of the edit
+	By           string            `json:"by"`
+	OldTitle     string            `json:"old_title"` // empty if the title was kept
+	NewTitle     string            `json:"new_title"`
+	Added        []string          `json:"added"`
+	Removed      []string          `json:"removed"`
+	Renamed      map[string]string `json:"renamed"`       // map[old option] = new option
+	ClearedVotes int               `json:"cleared_votes"` // ballots that lost a removed option
+}
+
+// Ballot represents a single voter's ballot
+type Ballot struct {
+	Choices []string       `json:"choices"` // chosen options in order of preference
+	Scores  map[string]int `json:"scores"`  // map[option] = score or votes, score and quadratic polls only
+	Weight  float64        `json:"weight"`  // voter's weight at the time of voting
+	CastAt  uint64         `json:"cast_at"` // unix time in milliseconds the ballot was last cast, 0 for anonymous polls
+	Reason  string         `json:"reason"`  // voter's rationale, shown without attribution
+}
+
+// Value returns the weight the ballot is counted with
+func (b Ballot) Value() float64 {
+	if b.Weight <= 0 {
+		return 1
+	}
+	return b.Weight
+}
+
+// FormatTime formats a unix time for messages
+func FormatTime(unix uint64) string {
+	return time.Unix(int64(unix), 0).UTC().Format("2006-01-02 15:04 MST")
+}
+
+// IsOpen reports whether the poll accepts votes
+func (p *Poll) IsOpen() bool {
+	return p.Status == StatusOpen
+}
+
+// IsClosed reports whether the poll has been closed
+func (p *Poll) IsClosed() bool {
+	return p.Status == StatusClosed
+}
+
+// IsDraft reports whether the poll is a draft
+func (p *Poll) IsDraft() bool {
+	return p.Status == StatusDraft
+}
+
+// IsScheduled reports whether the poll waits for its opening time
+func (p *Poll) IsScheduled() bool {
+	return p.Status == StatusScheduled
+}
+
+// OwnerIDs returns the users who manage the poll
+func (p *Poll) OwnerIDs() []string {
+	if len(p.Owners) == 0 {
+		return []string{p.CreatedBy}
+	}
+	return p.Owners
+}
+
+// IsOwner reports whether the user manages the poll
+func (p *Poll) IsOwner(userID string) bool {
+	for _, owner := range p.OwnerIDs() {
+		if owner == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTrashed reports whether the poll has been deleted and waits in the trash
+func (p *Poll) IsTrashed() bool {
+	return p.Status == StatusTrashed
+}
+
+// ClosedAt returns the unix time the poll was last closed. Polls closed before the lifecycle
+// history was kept fall back to their closing or opening time
+func (p *Poll) ClosedAt() uint64 {
+	for i := len(p.Events) - 1; i >= 0; i-- {
+		if p.Events[i].Type == EventClosed {
+			return p.Events[i].At
+		}
+	}
+	if p.ClosesAt != 0 {
+		return p.ClosesAt
+	}
+	if p.OpensAt != 0 {
+		return p.OpensAt
+	}
+	return p.CreatedAt
+}
+
+// WasReopened reports whether the poll has ever been reopened
+func (p *Poll) WasReopened() bool {
+	for _, event := range p.Events {
+		if event.Type == EventReopened {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRanked reports whether the poll collects ranked ballots
+func (p *Poll) IsRanked() bool {
+	return p.Settings.Type == PollTypeRanked || p.Settings.Type == PollTypeSTV
+}
+
+// IsSTV reports whether the poll elects several options by single transferable vote
+func (p *Poll) IsSTV() bool {
+	return p.Settings.Type == PollTypeSTV
+}
+
+// IsScored reports whether voters score every option
+func (p *Poll) IsScored() bool {
+	return p.Settings.Type == PollTypeScore
+}
+
+// IsQuadratic reports whether voters spend a credit budget on votes
+func (p *Poll) IsQuadratic() bool {
+	return p.Settings.Type == PollTypeQuadratic
+}
+
+// IsApproval reports whether voters may select several options
+func (p *Poll) IsApproval() bool {
+	return p.Settings.Type == PollTypeApproval
+}
+
+// HasOption reports whether the option is already offered or awaits approval
+func (p *Poll) HasOption(option string) bool {
+	for _, existing := range p.Options {
+		if existing == option {
+			return true
+		}
+	}
+	for _, suggestion := range p.Suggestions {
+		if suggestion.Option == option {
+			return true
+		}
+	}
+	return false
+}
+
+// VoterCount returns the number of voters who cast a ballot
+func (p *Poll) VoterCount() int {
+	return len(p.Votes)
+}
+
+// IsAnonymous reports whether the poll stores pseudonymous vote keys instead of user IDs
+func (p *Poll) IsAnonymous() bool {
+	return p.Settings.Visibility == VisibilityAnonymous
+}
+
+// IsPublic reports whether the poll's voters may be listed
+func (p *Poll) IsPublic() bool {
+	return p.Settings.Visibility == VisibilityPublic
+}
+
+// IsDecision reports whether the poll has a quorum or a pass threshold
+func (p *Poll) IsDecision() bool {
+	return p.Settings.Quorum > 0 || p.Settings.Threshold != ""
+}
+
+// CanChangeVote reports whether a cast ballot may still be changed or retracted at the given unix time
+func (p *Poll) CanChangeVote(now uint64) bool {
+	switch p.Settings.VotePolicy {
+	case VotePolicyLock:
+		return false
+	case VotePolicyWindow:
+		return now < p.Settings.ChangeUntil
+	default:
+		return true
+	}
+}
+
+// DueReminders returns the reminder offsets that are due at the given unix time and haven't been sent yet
+func (p *Poll) DueReminders(now uint64) []uint64 {
+	if !p.IsOpen() || p.ClosesAt == 0 || now >= p.ClosesAt {
+		return nil
+	}
+
+	var due []uint64
+	for _, offset := range p.Settings.Reminders {
+		if now+offset < p.ClosesAt {
+			continue
+		}
+		sent := false
+		for _, reminded := range p.Reminded {
+			if reminded == offset {
+				sent = true
+				break
+			}
+		}
+		if !sent {
+			due = append(due, offset)
+		}
+	}
+	return due
+}
+
+// IsWeighted reports whether some voters have a weight other than 1
+func (p *Poll) IsWeighted() bool {
+	return len(p.Settings.Weights) > 0
+}
+
+// WeightOf returns the voting weight of the user
+func (p *Poll) WeightOf(userID string) float64 {
+	if weight, ok := p.Settings.Weights[userID]; ok {
+		return weight
+	}
+	return 1
+}
+
+// TotalWeight returns the sum of weights of all cast ballots
+func (p *Poll) TotalWeight() float64 {
+	total := 0.0
+	for _, ballot := range p.Votes {
+		total += ballot.Value()
+	}
+	return total
 }
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDueReminders(t *testing.T) {
+	const closesAt = 100000
+
+	tests := []struct {
+		name     string
+		status   string
+		closesAt uint64
+		reminded []uint64
+		now      uint64
+		want     []uint64
+	}{
+		{name: "none due yet", status: StatusOpen, closesAt: closesAt, now: closesAt - 7200},
+		{name: "one due", status: StatusOpen, closesAt: closesAt, now: closesAt - 3600, want: []uint64{3600}},
+		{name: "all due", status: StatusOpen, closesAt: closesAt, now: closesAt - 60, want: []uint64{3600, 600}},
+		{name: "sent reminders are skipped", status: StatusOpen, closesAt: closesAt, reminded: []uint64{3600},
+			now: closesAt - 60, want: []uint64{600}},
+		{name: "closing time passed", status: StatusOpen, closesAt: closesAt, now: closesAt},
+		{name: "no closing time", status: StatusOpen, now: closesAt - 60},
+		{name: "closed poll", status: StatusClosed, closesAt: closesAt, now: closesAt - 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := &Poll{
+				Status:   tt.status,
+				ClosesAt: tt.closesAt,
+				Reminded: tt.reminded,
+				Settings: PollSettings{Reminders: []uint64{3600, 600}},
+			}
+
+			if got := poll.DueReminders(tt.now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DueReminders(%d) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
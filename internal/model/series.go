@@ -0,0 +1,33 @@
+package model
+
+// Series is a stored poll definition that opens a fresh poll on a cron schedule
+type Series struct {
+	ID        string       `json:"id"`
+	Title     string       `json:"title"`
+	Options   []string     `json:"options"`
+	Settings  PollSettings `json:"settings"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt uint64       `json:"created_at"`
+	ChannelID string       `json:"channel_id"`  // channel the instances are posted to
+	Schedule  string       `json:"schedule"`    // cron schedule in UTC
+	NextRunAt uint64       `json:"next_run_at"` // unix time of the next instance, 0 means the series is stopped
+	PollIDs   []string     `json:"poll_ids"`    // instances from the oldest to the latest
+}
+
+// OwnerIDs returns the users who manage the series
+func (s *Series) OwnerIDs() []string {
+	return []string{s.CreatedBy}
+}
+
+// IsStopped reports whether the series no longer opens new polls
+func (s *Series) IsStopped() bool {
+	return s.NextRunAt == 0
+}
+
+// LatestPollID returns the ID of the latest instance or an empty string if none was opened yet
+func (s *Series) LatestPollID() string {
+	if len(s.PollIDs) == 0 {
+		return ""
+	}
+	return s.PollIDs[len(s.PollIDs)-1]
+}
@@ -0,0 +1,23 @@
+package model
+
+// Template is a reusable poll definition saved for a team
+type Template struct {
+	TeamID    string       `json:"team_id"`
+	Name      string       `json:"name"`
+	Owner     string       `json:"owner"` // author of a private template, empty for templates shared with the team
+	Title     string       `json:"title"`
+	Options   []string     `json:"options"`
+	Settings  PollSettings `json:"settings"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt uint64       `json:"created_at"`
+}
+
+// OwnerIDs returns the users who manage the template
+func (t *Template) OwnerIDs() []string {
+	return []string{t.CreatedBy}
+}
+
+// IsPrivate reports whether only the template's author can see and use it
+func (t *Template) IsPrivate() bool {
+	return t.Owner != ""
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// voterKey returns the key the user's ballot is stored under. Anonymous polls use
+// a keyed HMAC of the poll and user IDs, so the same user gets unrelated keys in different polls
+func (s *Service) voterKey(poll *model.Poll, userID string) (string, error) {
+	if !poll.IsAnonymous() {
+		return userID, nil
+	}
+
+	if s.cfg.VoteKeySecret == "" {
+		return "", ErrNoVoteKey
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.cfg.VoteKeySecret))
+	mac.Write([]byte(poll.ID))
+	mac.Write([]byte{0})
+	mac.Write([]byte(userID))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// ballotLogAttrs returns log attributes describing the user's ballot.
+// Ballots of anonymous polls are never logged
+func ballotLogAttrs(poll *model.Poll, userID, prefix string, ballot model.Ballot) []any {
+	attrs := []any{"poll_id", poll.ID, "user_id", userID}
+	if poll.IsAnonymous() {
+		return attrs
+	}
+	return append(attrs,
+		prefix+"_choices", ballot.Choices,
+		prefix+"_scores", ballot.Scores,
+	)
+}
+
+// ballotError is a ballot error of an anonymous poll. The voter sees the full message,
+// logs only get the kind of the error without the options it names
+type ballotError struct {
+	err error
+}
+
+func (e ballotError) Error() string { return e.err.Error() }
+
+func (e ballotError) Unwrap() error { return e.err }
+
+// LogValue logs the innermost error, the sentinel without the details added to it
+func (e ballotError) LogValue() slog.Value {
+	kind := e.err
+	for errors.Unwrap(kind) != nil {
+		kind = errors.Unwrap(kind)
+	}
+	return slog.StringValue(kind.Error())
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hard-gainer/voting-bot/internal/db"
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// archiveSearchLimit is the number of archived polls shown for a search
+const archiveSearchLimit = 50
+
+// day is the length of a retention day in seconds
+const day = uint64(24 * time.Hour / time.Second)
+
+// RunRetention archives old closed polls and purges expired archived polls every interval
+// until the context is cancelled
+func (s *Service) RunRetention(ctx context.Context, interval time.Duration) {
+	slog.Info("Starting retention worker", "interval", interval,
+		"archive_after_days", s.cfg.ArchiveAfterDays, "purge_after_days", s.cfg.PurgeAfterDays)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Retention worker stopped")
+			return
+		case <-ticker.C:
+			if _, _, err := s.enforceRetention(ctx); err != nil {
+				slog.Error("Failed to enforce retention policy", "error", err)
+			}
+		}
+	}
+}
+
+// EnforceRetention runs the retention policy right away. Only system admins can run it.
+// It returns the number of archived and purged polls
+func (s *Service) EnforceRetention(ctx context.Context, userID string) (int, int, error) {
+	slog.Info("Enforcing retention policy on demand", "user_id", userID)
+
+	if err := s.requireAdmin(userID); err != nil {
+		return 0, 0, err
+	}
+	return s.enforceRetention(ctx)
+}
+
+// enforceRetention moves polls closed longer than the archive period to the archive
+// and purges archived polls closed longer than the purge period
+func (s *Service) enforceRetention(ctx context.Context) (int, int, error) {
+	now := uint64(time.Now().Unix())
+
+	closed, err := s.storage.ListClosedPolls(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list closed polls: %w", err)
+	}
+
+	archived := 0
+	archiveBefore := now - uint64(s.cfg.ArchiveAfterDays)*day
+	for _, poll := range closed {
+		closedAt := poll.ClosedAt()
+		if closedAt > archiveBefore {
+			continue
+		}
+
+		if err := s.storage.ArchivePoll(ctx, &model.ArchivedPoll{Poll: poll, ClosedAt: closedAt, ArchivedAt: now}); err != nil {
+			slog.Error("Failed to archive poll", "poll_id", poll.ID, "error", err)
+			continue
+		}
+		archived++
+	}
+
+	expired, err := s.storage.ListExpiredArchive(ctx, now-uint64(s.cfg.PurgeAfterDays)*day)
+	if err != nil {
+		return archived, 0, fmt.Errorf("failed to list expired archived polls: %w", err)
+	}
+
+	purged := 0
+	for _, item := range expired {
+		if err := s.storage.DeleteArchivedPoll(ctx, item.Poll.ID); err != nil {
+			slog.Error("Failed to purge archived poll", "poll_id", item.Poll.ID, "error", err)
+			continue
+		}
+		purged++
+	}
+
+	if archived > 0 || purged > 0 {
+		slog.Info("Retention policy enforced", "archived", archived, "purged", purged)
+	}
+	return archived, purged, nil
+}
+
+// requireAdmin checks that the user is a system admin
+func (s *Service) requireAdmin(userID string) error {
+	if s.directory == nil {
+		slog.Warn("No directory set, admin commands are unavailable")
+		return ErrNotAuthorized
+	}
+
+	admin, err := s.directory.IsSystemAdmin(userID)
+	if err != nil {
+		return fmt.Errorf("failed to check user roles: %w", err)
+	}
+	if !admin {
+		slog.Info("Unauthorized attempt to run admin command", "user_id", userID)
+		return ErrNotAuthorized
+	}
+	return nil
+}
+
+// SearchArchive formats the archived polls whose ID, creator or channel equals the query
+// or whose title contains it. Only system admins can search the archive
+func (s *Service) SearchArchive(ctx context.Context, query, userID string) (string, error) {
+	slog.Info("Searching archive", "user_id", userID)
+
+	if err := s.requireAdmin(userID); err != nil {
+		return "", err
+	}
+
+	all, err := s.storage.ListArchivedPolls(ctx)
+	if err != nil {
+		slog.Error("Failed to list archived polls", "error", err)
+		return "", fmt.Errorf("failed to list archived polls: %w", err)
+	}
+
+	query = strings.TrimSpace(query)
+	lower := strings.ToLower(query)
+
+	matches := make([]*model.ArchivedPoll, 0)
+	for _, item := range all {
+		poll := item.Poll
+		if query == "" || poll.ID == query || poll.CreatedBy == query || poll.ChannelID == query ||
+			strings.Contains(strings.ToLower(poll.Title), lower) {
+			matches = append(matches, item)
+		}
+	}
+
+	// most recently closed first
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].ClosedAt > matches[j].ClosedAt
+	})
+
+	result := fmt.Sprintf("### Archive: %d of %d polls match\n\n", len(matches), len(all))
+	if len(matches) == 0 {
+		return result + "No archived polls found.", nil
+	}
+	if len(matches) > archiveSearchLimit {
+		result += fmt.Sprintf("_Showing the latest %d, narrow the search to see the rest._\n\n", archiveSearchLimit)
+		matches = matches[:archiveSearchLimit]
+	}
+
+	for _, item := range matches {
+		result += fmt.Sprintf("- **%s** (ID: `%s`), closed at %s, purged at %s\n", item.Poll.Title, item.Poll.ID,
+			model.FormatTime(item.ClosedAt), model.FormatTime(item.ClosedAt+uint64(s.cfg.PurgeAfterDays)*day))
+	}
+
+	return result + "\nTo see an archived poll: `/poll-admin archive show [poll-id]`", nil
+}
+
+// FormatArchivedPoll formats the final counts of an archived poll. Only system admins can see the archive
+func (s *Service) FormatArchivedPoll(ctx context.Context, pollID, userID string) (string, error) {
+	if err := s.requireAdmin(userID); err != nil {
+		return "", err
+	}
+
+	item, err := s.storage.GetArchivedPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return "", ErrPollNotFound
+		}
+		return "", fmt.Errorf("failed to get archived poll: %w", err)
+	}
+
+	poll := item.Poll
+	result := fmt.Sprintf("### Archived Poll: %s\n\n**ID:** %s\n\n", poll.Title, poll.ID)
+	result += fmt.Sprintf("**Created:** %s by %s in channel %s\n\n", model.FormatTime(poll.CreatedAt), poll.CreatedBy, poll.ChannelID)
+	result += fmt.Sprintf("**Closed:** %s, archived %s, purged at %s\n\n", model.FormatTime(item.ClosedAt),
+		model.FormatTime(item.ArchivedAt), model.FormatTime(item.ClosedAt+uint64(s.cfg.PurgeAfterDays)*day))
+	result += fmt.Sprintf("**Type:** %s, **voters:** %d\n\n", poll.Settings.Type, poll.VoterCount())
+
+	counts := countVotes(poll)
+	for _, option := range poll.Options {
+		result += fmt.Sprintf("- %s: %g\n", option, counts[option])
+	}
+
+	if winners, err := winnersOf(poll); err == nil && len(winners) > 0 && !poll.IsSTV() {
+		result += fmt.Sprintf("\n**Winner:** %s\n", strings.Join(winners, ", "))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,323 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+	"github.com/hard-gainer/voting-bot/internal/tally"
+)
+
+// validateOptions checks that poll options are not empty and unique
+func validateOptions(options []string) error {
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		if option == "" {
+			return fmt.Errorf("%w: empty option", ErrInvalidOption)
+		}
+		if seen[option] {
+			return fmt.Errorf("%w: duplicate option %q", ErrInvalidOption, option)
+		}
+		seen[option] = true
+	}
+	return nil
+}
+
+// normalizeSettings validates poll settings and fills in defaults
+func normalizeSettings(settings model.PollSettings, options []string) (model.PollSettings, error) {
+	if settings.Type != model.PollTypeApproval && settings.MaxChoices != 0 {
+		return settings, fmt.Errorf("%w: choice limit applies to approval polls only", ErrInvalidType)
+	}
+
+	if settings.Type != model.PollTypeSTV && settings.Seats != 0 {
+		return settings, fmt.Errorf("%w: seats apply to stv polls only", ErrInvalidType)
+	}
+
+	switch settings.Visibility {
+	case "":
+		settings.Visibility = model.VisibilityConfidential
+	case model.VisibilityConfidential, model.VisibilityPublic:
+	case model.VisibilityAnonymous:
+		if len(settings.Weights) > 0 {
+			return settings, errors.New("weighted polls cannot be anonymous: weights would identify voters")
+		}
+	default:
+		return settings, fmt.Errorf("unsupported visibility: %s", settings.Visibility)
+	}
+
+	if settings.Type != model.PollTypeQuadratic && settings.Credits != 0 {
+		return settings, fmt.Errorf("%w: credits apply to quadratic polls only", ErrInvalidType)
+	}
+
+	if settings.Quorum != 0 || settings.Threshold != "" {
+		if settings.Type != "" && settings.Type != model.PollTypeSingle && settings.Type != model.PollTypeApproval {
+			return settings, fmt.Errorf("%w: quorum and threshold apply to single choice and approval polls only", ErrInvalidType)
+		}
+		if settings.Quorum < 0 {
+			return settings, errors.New("quorum must be positive")
+		}
+		// 0 means the quorum is a fixed number of voters
+		if settings.QuorumPercent < 0 || settings.QuorumPercent > 100 {
+			return settings, errors.New("quorum percentage must be between 0 and 100")
+		}
+		if settings.Threshold != "" {
+			if _, _, err := parseThreshold(settings.Threshold); err != nil {
+				return settings, err
+			}
+		}
+	}
+
+	switch settings.VotePolicy {
+	case "":
+		settings.VotePolicy = model.VotePolicyChange
+	case model.VotePolicyChange, model.VotePolicyLock:
+	case model.VotePolicyWindow:
+		if settings.ChangeUntil <= uint64(time.Now().Unix()) {
+			return settings, errors.New("vote change cutoff must be in the future")
+		}
+	default:
+		return settings, fmt.Errorf("unsupported vote policy: %s", settings.VotePolicy)
+	}
+
+	if settings.VotePolicy != model.VotePolicyWindow && settings.ChangeUntil != 0 {
+		return settings, errors.New("vote change cutoff applies to the window policy only")
+	}
+
+	if err := validateTieBreak(settings); err != nil {
+		return settings, err
+	}
+
+	reminders, err := normalizeReminders(settings.Reminders)
+	if err != nil {
+		return settings, err
+	}
+	settings.Reminders = reminders
+
+	for userID, weight := range settings.Weights {
+		if weight <= 0 {
+			return settings, fmt.Errorf("weight of user %s must be positive", userID)
+		}
+	}
+
+	switch settings.Type {
+	case "", model.PollTypeSingle:
+		settings.Type = model.PollTypeSingle
+		if settings.Method != "" {
+			return settings, fmt.Errorf("%w: methods apply to ranked polls only", ErrInvalidMethod)
+		}
+	case model.PollTypeApproval:
+		if settings.Method != "" {
+			return settings, fmt.Errorf("%w: methods apply to ranked polls only", ErrInvalidMethod)
+		}
+		if settings.MaxChoices < 0 || settings.MaxChoices > len(options) {
+			return settings, fmt.Errorf("choice limit must be between 1 and %d", len(options))
+		}
+	case model.PollTypeRanked:
+		if settings.Method == "" {
+			settings.Method = model.MethodIRV
+		}
+		if _, ok := tally.Get(settings.Method); !ok || !isRankedMethod(settings.Method) {
+			return settings, fmt.Errorf("%w: %s", ErrInvalidMethod, settings.Method)
+		}
+	case model.PollTypeScore:
+		if settings.Method != "" && settings.Method != model.MethodSTAR {
+			return settings, fmt.Errorf("%w: score polls are counted with STAR", ErrInvalidMethod)
+		}
+		settings.Method = model.MethodSTAR
+	case model.PollTypeQuadratic:
+		if settings.Method != "" && settings.Method != model.MethodQuadratic {
+			return settings, fmt.Errorf("%w: quadratic polls are counted by net votes", ErrInvalidMethod)
+		}
+		settings.Method = model.MethodQuadratic
+		if settings.Credits == 0 {
+			settings.Credits = model.DefaultCredits
+		}
+		if settings.Credits < 1 || settings.Credits > model.MaxCredits {
+			return settings, fmt.Errorf("credit budget must be between 1 and %d", model.MaxCredits)
+		}
+	case model.PollTypeSTV:
+		if settings.Method != "" && settings.Method != model.MethodSTV {
+			return settings, fmt.Errorf("%w: stv polls are counted with STV", ErrInvalidMethod)
+		}
+		settings.Method = model.MethodSTV
+		if settings.Seats == 0 {
+			settings.Seats = 1
+		}
+		if settings.Seats < 1 || settings.Seats >= len(options) {
+			return settings, fmt.Errorf("number of seats must be between 1 and %d", len(options)-1)
+		}
+	default:
+		return settings, fmt.Errorf("%w: %s", ErrInvalidType, settings.Type)
+	}
+
+	return settings, nil
+}
+
+// isRankedMethod reports whether the method counts ranked ballots of single-winner polls
+func isRankedMethod(method string) bool {
+	switch method {
+	case model.MethodSTAR, model.MethodSTV, model.MethodQuadratic:
+		return false
+	default:
+		return true
+	}
+}
+
+// buildBallot validates the voter's choices against the poll and builds a ballot
+func buildBallot(poll *model.Poll, choices []string) (model.Ballot, error) {
+	if len(choices) == 0 {
+		return model.Ballot{}, fmt.Errorf("%w: no option selected", ErrInvalidBallot)
+	}
+
+	switch {
+	case poll.IsScored():
+		return buildScoreBallot(poll, choices)
+	case poll.IsQuadratic():
+		return buildQuadraticBallot(poll, choices)
+	}
+
+	switch {
+	case poll.IsApproval():
+		if poll.Settings.MaxChoices > 0 && len(choices) > poll.Settings.MaxChoices {
+			return model.Ballot{}, fmt.Errorf("%w: at most %d options can be selected",
+				ErrInvalidBallot, poll.Settings.MaxChoices)
+		}
+	case !poll.IsRanked() && len(choices) > 1:
+		return model.Ballot{}, fmt.Errorf("%w: only one option can be selected", ErrInvalidBallot)
+	}
+
+	resolved := make([]string, 0, len(choices))
+	seen := make(map[string]bool, len(choices))
+	for _, choice := range choices {
+		option, err := resolveOption(poll, choice)
+		if err != nil {
+			return model.Ballot{}, err
+		}
+		if seen[option] {
+			return model.Ballot{}, fmt.Errorf("%w: option %q is selected more than once", ErrInvalidBallot, option)
+		}
+		seen[option] = true
+		resolved = append(resolved, option)
+	}
+
+	return model.Ballot{Choices: resolved}, nil
+}
+
+// buildScoreBallot parses "option=score" assignments. Options left out are scored with the minimum score
+func buildScoreBallot(poll *model.Poll, assignments []string) (model.Ballot, error) {
+	assigned, err := parseAssignments(poll, assignments)
+	if err != nil {
+		return model.Ballot{}, err
+	}
+
+	scores := make(map[string]int, len(poll.Options))
+	for _, option := range poll.Options {
+		score, ok := assigned[option]
+		if !ok {
+			score = model.MinScore
+		}
+		if score < model.MinScore || score > model.MaxScore {
+			return model.Ballot{}, fmt.Errorf("%w: score for %q must be a number from %d to %d",
+				ErrInvalidScore, option, model.MinScore, model.MaxScore)
+		}
+		scores[option] = score
+	}
+
+	return model.Ballot{Scores: scores}, nil
+}
+
+// buildQuadraticBallot parses "option=votes" assignments, negative votes count against the option.
+// Casting k votes on an option costs k² credits, the total cost must fit the poll's budget
+func buildQuadraticBallot(poll *model.Poll, assignments []string) (model.Ballot, error) {
+	votes, err := parseAssignments(poll, assignments)
+	if err != nil {
+		return model.Ballot{}, err
+	}
+
+	// vote counts are checked before squaring them, a huge count would overflow the cost
+	limit := maxQuadraticVotes(poll.Settings.Credits)
+	for option, k := range votes {
+		if k > limit || k < -limit {
+			return model.Ballot{}, fmt.Errorf("%w: %d votes for %q cost more than the budget of %d credits, at most %d fit",
+				ErrBudgetExceeded, k, option, poll.Settings.Credits, limit)
+		}
+	}
+
+	cost := quadraticCost(votes)
+	if cost > poll.Settings.Credits {
+		return model.Ballot{}, fmt.Errorf("%w: these votes cost %d credits, the budget is %d",
+			ErrBudgetExceeded, cost, poll.Settings.Credits)
+	}
+
+	return model.Ballot{Scores: votes}, nil
+}
+
+// quadraticCost returns the number of credits spent on the votes
+func quadraticCost(votes map[string]int) int {
+	cost := 0
+	for _, k := range votes {
+		cost += k * k
+	}
+	return cost
+}
+
+// maxQuadraticVotes returns the most votes for or against a single option the budget pays for
+func maxQuadraticVotes(credits int) int {
+	k := int(math.Sqrt(float64(credits)))
+	for k > 0 && k*k > credits {
+		k--
+	}
+	for (k+1)*(k+1) <= credits {
+		k++
+	}
+	return k
+}
+
+// parseAssignments parses "option=number" assignments and checks the options against the poll
+func parseAssignments(poll *model.Poll, assignments []string) (map[string]int, error) {
+	values := make(map[string]int, len(assignments))
+	for _, assignment := range assignments {
+		i := strings.LastIndex(assignment, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("%w: expected option=number, got %q", ErrInvalidScore, assignment)
+		}
+
+		option, err := resolveOption(poll, assignment[:i])
+		if err != nil {
+			return nil, err
+		}
+		if _, seen := values[option]; seen {
+			return nil, fmt.Errorf("%w: option %q is assigned more than once", ErrInvalidBallot, option)
+		}
+
+		value, err := strconv.Atoi(strings.TrimSpace(assignment[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not a whole number", ErrInvalidScore, assignment[i+1:])
+		}
+		values[option] = value
+	}
+
+	return values, nil
+}
+
+// supportedOptions returns the options the ballot supports
+func supportedOptions(poll *model.Poll, ballot model.Ballot) []string {
+	switch {
+	case poll.IsScored() || poll.IsQuadratic():
+		var options []string
+		for _, option := range poll.Options {
+			if ballot.Scores[option] != 0 {
+				options = append(options, option)
+			}
+		}
+		return options
+	case poll.IsRanked() && len(ballot.Choices) > 0:
+		return ballot.Choices[:1]
+	default:
+		return ballot.Choices
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+func TestBuildQuadraticBallot(t *testing.T) {
+	poll := &model.Poll{
+		Options:  []string{"A", "B"},
+		Settings: model.PollSettings{Type: model.PollTypeQuadratic, Credits: 100},
+	}
+
+	tests := []struct {
+		name        string
+		assignments []string
+		scores      map[string]int
+		err         error
+	}{
+		{name: "within the budget", assignments: []string{"A=3", "B=-4"}, scores: map[string]int{"A": 3, "B": -4}},
+		{name: "whole budget on one option", assignments: []string{"A=10"}, scores: map[string]int{"A": 10}},
+		{name: "total over the budget", assignments: []string{"A=10", "B=1"}, err: ErrBudgetExceeded},
+		{name: "one option over the budget", assignments: []string{"B=-11"}, err: ErrBudgetExceeded},
+		{name: "square overflows 32 bits", assignments: []string{"A=4294967296"}, err: ErrBudgetExceeded},
+		{name: "square overflows 64 bits", assignments: []string{"A=-9223372036854775807"}, err: ErrBudgetExceeded},
+		{name: "not a number", assignments: []string{"A=x"}, err: ErrInvalidScore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ballot, err := buildQuadraticBallot(poll, tt.assignments)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(ballot.Scores, tt.scores) {
+				t.Errorf("scores = %v, want %v", ballot.Scores, tt.scores)
+			}
+		})
+	}
+}
+
+func TestMaxQuadraticVotes(t *testing.T) {
+	tests := []struct {
+		credits int
+		want    int
+	}{
+		{1, 1},
+		{3, 1},
+		{4, 2},
+		{99, 9},
+		{100, 10},
+		{model.MaxCredits, 1000},
+	}
+
+	for _, tt := range tests {
+		if got := maxQuadraticVotes(tt.credits); got != tt.want {
+			t.Errorf("maxQuadraticVotes(%d) = %d, want %d", tt.credits, got, tt.want)
+		}
+	}
+}
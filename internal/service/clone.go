@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/hard-gainer/voting-bot/internal/db"
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// ClonePoll opens a new poll with the title, options and settings of an open or closed poll.
+// A poll that closed at a fixed time stays open as long as the original did, a vote change
+// window keeps its length. A positive quorum replaces the original's, for clones posted to
+// channels of a different size
+func (s *Service) ClonePoll(ctx context.Context, pollID, channelID string, quorum int, userID string) (*model.Poll, error) {
+	slog.Info("Cloning poll", "poll_id", pollID, "channel_id", channelID, "user_id", userID)
+
+	source, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrPollNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if source.IsTrashed() {
+		return nil, ErrTrashed
+	}
+
+	if source.IsDraft() || source.IsScheduled() {
+		return nil, fmt.Errorf("%w: only open and closed polls can be cloned", ErrPollNotOpen)
+	}
+
+	settings := source.Settings
+	if settings.Duration == 0 && source.ClosesAt > source.OpensAt {
+		settings.Duration = source.ClosesAt - source.OpensAt
+	}
+	if settings.VotePolicy == model.VotePolicyWindow {
+		window := uint64(0)
+		if settings.ChangeUntil > source.OpensAt {
+			window = settings.ChangeUntil - source.OpensAt
+		}
+		settings.ChangeUntil = uint64(time.Now().Unix()) + window
+	}
+	if quorum > 0 {
+		settings.Quorum = quorum
+	}
+
+	poll, err := s.newPoll(source.Title, source.Options, userID, channelID, 0, settings, model.StatusOpen)
+	if err != nil {
+		return nil, err
+	}
+	poll.ClonedFrom = source.ID
+
+	if err := s.storage.CreatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to create clone", "poll_id", pollID, "error", err)
+		return nil, fmt.Errorf("failed to create poll: %w", err)
+	}
+
+	slog.Info("Poll cloned", "poll_id", poll.ID, "cloned_from", source.ID)
+	return poll, nil
+}
+
+// FormatComparison formats the results of a cloned poll side by side with the poll it was cloned from
+func (s *Service) FormatComparison(ctx context.Context, pollID string) (string, error) {
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return "", ErrPollNotFound
+		}
+		return "", fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if poll.ClonedFrom == "" {
+		return "", ErrNotCloned
+	}
+
+	source, err := s.storage.GetPoll(ctx, poll.ClonedFrom)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return "", fmt.Errorf("original poll %s no longer exists: %w", poll.ClonedFrom, ErrPollNotFound)
+		}
+		return "", fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	// options added to either poll after cloning are compared too
+	options := append([]string(nil), source.Options...)
+	for _, option := range poll.Options {
+		if !contains(options, option) {
+			options = append(options, option)
+		}
+	}
+
+	shares := poll.Settings.Type == model.PollTypeSingle || poll.Settings.Type == model.PollTypeApproval
+	before, after := seriesValues(source, shares), seriesValues(poll, shares)
+
+	comparison := fmt.Sprintf("### Comparison: %s\n\n", poll.Title)
+	comparison += fmt.Sprintf("| | Original (`%s`) | Re-run (`%s`) | Shift |\n|---|---|---|---|\n", source.ID, poll.ID)
+	comparison += fmt.Sprintf("| Opened | %s | %s | |\n", model.FormatTime(source.OpensAt), model.FormatTime(poll.OpensAt))
+	comparison += fmt.Sprintf("| Voters | %d | %d | %s |\n", source.VoterCount(), poll.VoterCount(),
+		formatShift(float64(poll.VoterCount()-source.VoterCount()), false))
+
+	for _, option := range options {
+		comparison += fmt.Sprintf("| %s | %s | %s | %s |\n", option,
+			formatComparedValue(source, option, before[option], shares),
+			formatComparedValue(poll, option, after[option], shares),
+			formatShift(after[option]-before[option], shares))
+	}
+
+	comparison += "\n"
+	for _, compared := range []struct {
+		name string
+		poll *model.Poll
+	}{{"Original", source}, {"Re-run", poll}} {
+		winners, err := winnersOf(compared.poll)
+		if err != nil || len(winners) == 0 || compared.poll.IsSTV() {
+			continue
+		}
+		label := "leader"
+		if compared.poll.IsClosed() {
+			label = "winner"
+		}
+		comparison += fmt.Sprintf("**%s %s:** %s\n", compared.name, label, strings.Join(winners, ", "))
+	}
+
+	return comparison, nil
+}
+
+// formatComparedValue formats an option's value in the comparison, options the poll doesn't offer are marked
+func formatComparedValue(poll *model.Poll, option string, value float64, shares bool) string {
+	if !contains(poll.Options, option) {
+		return "-"
+	}
+	return formatSeriesValue(value, shares)
+}
+
+// contains reports whether the options include the option
+func contains(options []string, option string) bool {
+	for _, existing := range options {
+		if existing == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// decision is the outcome of a poll with a quorum or a pass threshold
+type decision struct {
+	outcome string
+	leader  string  // leading option, empty on a tie
+	share   float64 // leading option's share of the votes in percent
+	voters  int
+}
+
+// parseThreshold returns the share of votes the threshold requires.
+// Strict thresholds must be exceeded, other thresholds must be reached
+func parseThreshold(threshold string) (float64, bool, error) {
+	switch threshold {
+	case model.ThresholdMajority:
+		return 0.5, true, nil
+	case model.ThresholdTwoThirds:
+		return 2.0 / 3.0, false, nil
+	}
+
+	raw, ok := strings.CutSuffix(threshold, "%")
+	if !ok {
+		return 0, false, fmt.Errorf("unsupported threshold %q: use majority, two-thirds or a percentage like 60%%", threshold)
+	}
+
+	percent, err := strconv.ParseFloat(raw, 64)
+	if err != nil || percent <= 0 || percent > 100 {
+		return 0, false, fmt.Errorf("threshold percentage must be a number from 1 to 100, got %q", threshold)
+	}
+	return percent / 100, false, nil
+}
+
+// describeThreshold returns a human-readable description of the threshold
+func describeThreshold(threshold string) string {
+	switch threshold {
+	case model.ThresholdMajority:
+		return "simple majority (more than 50%)"
+	case model.ThresholdTwoThirds:
+		return "two-thirds (at least 66.7%)"
+	default:
+		return "at least " + threshold
+	}
+}
+
+// decide applies the poll's quorum and threshold to the weighted results.
+// The quorum counts voters, the threshold compares the leading option's share of the total weight.
+// A tie for the lead fails the poll
+func decide(poll *model.Poll, results map[string]float64) decision {
+	d := decision{voters: poll.VoterCount()}
+
+	if d.voters < poll.Settings.Quorum {
+		d.outcome = model.OutcomeNoQuorum
+		return d
+	}
+
+	best := 0.0
+	for _, option := range poll.Options {
+		switch {
+		case results[option] > best:
+			best = results[option]
+			d.leader = option
+		case results[option] == best:
+			d.leader = ""
+		}
+	}
+
+	if totalWeight := poll.TotalWeight(); totalWeight > 0 {
+		d.share = best / totalWeight * 100
+	}
+
+	if d.leader == "" {
+		d.outcome = model.OutcomeFailed
+		return d
+	}
+
+	d.outcome = model.OutcomePassed
+	if poll.Settings.Threshold != "" {
+		// thresholds are validated when the poll is created
+		ratio, strict, _ := parseThreshold(poll.Settings.Threshold)
+		share := best / poll.TotalWeight()
+		if share < ratio || (strict && share == ratio) {
+			d.outcome = model.OutcomeFailed
+		}
+	}
+
+	return d
+}
+
+// formatRules describes the quorum and threshold of an active poll
+func formatRules(poll *model.Poll) string {
+	var rules string
+	if poll.Settings.Quorum > 0 {
+		rules += fmt.Sprintf("**Quorum: %d of %d voters", poll.VoterCount(), poll.Settings.Quorum)
+		if poll.Settings.QuorumPercent > 0 {
+			rules += fmt.Sprintf(" (%d%% of channel members)", poll.Settings.QuorumPercent)
+		}
+		rules += "**\n\n"
+	}
+	if poll.Settings.Threshold != "" {
+		rules += fmt.Sprintf("**Pass threshold: %s**\n\n", describeThreshold(poll.Settings.Threshold))
+	}
+	return rules
+}
+
+// formatDecision states the outcome of a closed decision poll
+func formatDecision(poll *model.Poll, d decision) string {
+	outcome := fmt.Sprintf("## Outcome: %s\n\n", d.outcome)
+
+	switch {
+	case d.outcome == model.OutcomeNoQuorum:
+		outcome += fmt.Sprintf("Only %d of the required %d voters took part.\n\n", d.voters, poll.Settings.Quorum)
+	case d.leader == "":
+		outcome += "No option is ahead: the leading options are tied or no votes were cast.\n\n"
+	case poll.Settings.Threshold == "":
+		outcome += fmt.Sprintf("**%s** leads with %.1f%% of the votes.\n\n", d.leader, d.share)
+	case d.outcome == model.OutcomePassed:
+		outcome += fmt.Sprintf("**%s** received %.1f%% of the votes, meeting the %s threshold.\n\n",
+			d.leader, d.share, describeThreshold(poll.Settings.Threshold))
+	default:
+		outcome += fmt.Sprintf("**%s** received %.1f%% of the votes, short of the %s threshold.\n\n",
+			d.leader, d.share, describeThreshold(poll.Settings.Threshold))
+	}
+
+	return outcome
+}
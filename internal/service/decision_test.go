@@ -0,0 +1,131 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+func TestParseThreshold(t *testing.T) {
+	tests := []struct {
+		threshold string
+		ratio     float64
+		strict    bool
+		ok        bool
+	}{
+		{model.ThresholdMajority, 0.5, true, true},
+		{model.ThresholdTwoThirds, 2.0 / 3.0, false, true},
+		{"60%", 0.6, false, true},
+		{"100%", 1, false, true},
+		{"0%", 0, false, false},
+		{"101%", 0, false, false},
+		{"abc%", 0, false, false},
+		{"60", 0, false, false},
+		{"unanimous", 0, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.threshold, func(t *testing.T) {
+			ratio, strict, err := parseThreshold(tt.threshold)
+			if (err == nil) != tt.ok {
+				t.Fatalf("parseThreshold(%q) err = %v, want ok = %v", tt.threshold, err, tt.ok)
+			}
+			if ratio != tt.ratio || strict != tt.strict {
+				t.Errorf("parseThreshold(%q) = %v, %v, want %v, %v", tt.threshold, ratio, strict, tt.ratio, tt.strict)
+			}
+		})
+	}
+}
+
+// voters returns n ballots with the default weight
+func voters(n int) map[string]model.Ballot {
+	votes := make(map[string]model.Ballot, n)
+	for i := 0; i < n; i++ {
+		votes[fmt.Sprintf("user%d", i)] = model.Ballot{}
+	}
+	return votes
+}
+
+func TestDecide(t *testing.T) {
+	tests := []struct {
+		name      string
+		voters    int
+		quorum    int
+		threshold string
+		results   map[string]float64
+		outcome   string
+		leader    string
+		share     float64
+	}{
+		{
+			name:    "quorum not reached",
+			voters:  2,
+			quorum:  3,
+			results: map[string]float64{"Yes": 2},
+			outcome: model.OutcomeNoQuorum,
+		},
+		{
+			name:    "leader passes without a threshold",
+			voters:  3,
+			quorum:  3,
+			results: map[string]float64{"Yes": 2, "No": 1},
+			outcome: model.OutcomePassed,
+			leader:  "Yes",
+			share:   200.0 / 3,
+		},
+		{
+			name:      "majority must be exceeded",
+			voters:    4,
+			threshold: model.ThresholdMajority,
+			results:   map[string]float64{"Yes": 2, "No": 1},
+			outcome:   model.OutcomeFailed,
+			leader:    "Yes",
+			share:     50,
+		},
+		{
+			name:      "two-thirds may be reached exactly",
+			voters:    3,
+			threshold: model.ThresholdTwoThirds,
+			results:   map[string]float64{"Yes": 2, "No": 1},
+			outcome:   model.OutcomePassed,
+			leader:    "Yes",
+			share:     200.0 / 3,
+		},
+		{
+			name:      "percentage below the threshold",
+			voters:    5,
+			threshold: "70%",
+			results:   map[string]float64{"Yes": 3, "No": 2},
+			outcome:   model.OutcomeFailed,
+			leader:    "Yes",
+			share:     60,
+		},
+		{
+			name:    "tie for the lead fails",
+			voters:  2,
+			results: map[string]float64{"Yes": 1, "No": 1},
+			outcome: model.OutcomeFailed,
+			share:   50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := &model.Poll{
+				Options:  []string{"Yes", "No"},
+				Votes:    voters(tt.voters),
+				Settings: model.PollSettings{Quorum: tt.quorum, Threshold: tt.threshold},
+			}
+
+			d := decide(poll, tt.results)
+			if d.outcome != tt.outcome || d.leader != tt.leader || d.voters != tt.voters {
+				t.Errorf("decide() = %+v, want outcome %q, leader %q, voters %d", d, tt.outcome, tt.leader, tt.voters)
+			}
+			if math.Abs(d.share-tt.share) > 1e-9 {
+				t.Errorf("share = %v, want %v", d.share, tt.share)
+			}
+		})
+	}
+}
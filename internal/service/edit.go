@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hard-gainer/voting-bot/internal/db"
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// editHistoryLimit is the number of latest edits shown in a poll's edit history
+const editHistoryLimit = 20
+
+// EditPoll changes the title and options of a poll. Drafts and scheduled polls can be changed freely.
+// Votes for a renamed option carry over to its new text, votes for a removed option are cleared
+// and their voters are told by DM. Options of a closed poll can only be renamed
+func (s *Service) EditPoll(ctx context.Context, pollID string, edit model.PollEdit, userID string) (*model.Poll, error) {
+	slog.Info("Editing poll", "poll_id", pollID, "user_id", userID)
+
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrPollNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if err := authorize(poll, userID, "edit poll"); err != nil {
+		return nil, err
+	}
+
+	if poll.IsTrashed() {
+		return nil, ErrTrashed
+	}
+
+	if poll.IsClosed() && (len(edit.Add) > 0 || len(edit.Remove) > 0) {
+		return nil, fmt.Errorf("options of a closed poll can only be renamed, reopen it with `/poll-reopen %s` first", poll.ID)
+	}
+
+	options, renamed, removed, err := applyOptionEdit(poll, edit)
+	if err != nil {
+		return nil, err
+	}
+	if len(options) < 2 {
+		return nil, errors.New("poll must have at least two options")
+	}
+	if err := validateOptions(options); err != nil {
+		return nil, err
+	}
+
+	settings := poll.Settings
+	if poll.IsDraft() || poll.IsScheduled() {
+		if settings, err = normalizeSettings(poll.Settings, options); err != nil {
+			return nil, err
+		}
+	} else if err := validateOptionCount(settings, options); err != nil {
+		// the rest of the settings were checked when the poll opened, a passed vote change cutoff is expected
+		return nil, err
+	}
+
+	record := model.EditRecord{
+		At:      uint64(time.Now().Unix()),
+		By:      userID,
+		Renamed: renamed,
+		Removed: removed,
+	}
+	for _, option := range options {
+		if !contains(poll.Options, option) && !isRenameTarget(renamed, option) {
+			record.Added = append(record.Added, option)
+		}
+	}
+	if title := strings.TrimSpace(edit.Title); title != "" && title != poll.Title {
+		record.OldTitle, record.NewTitle = poll.Title, title
+		poll.Title = title
+	}
+
+	affected := migrateBallots(poll, renamed, removed)
+	record.ClearedVotes = len(affected)
+
+	dropped := dropOfferedSuggestions(poll, options)
+
+	poll.Options = options
+	poll.Settings = settings
+	poll.Edits = append(poll.Edits, record)
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to update poll", "poll_id", pollID, "error", err)
+		return nil, fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	slog.Info("Poll edited", "poll_id", pollID, "options_count", len(options),
+		"renamed", len(renamed), "removed", len(removed), "cleared_votes", len(affected),
+		"dropped_suggestions", dropped)
+
+	if len(affected) > 0 {
+		s.notifyClearedVoters(poll, affected, removed)
+	}
+
+	return poll, nil
+}
+
+// applyOptionEdit returns the poll's options with the renames, removals and additions applied,
+// along with the renamed options and the removed ones. Existing options are addressed the same
+// way as in votes
+func applyOptionEdit(poll *model.Poll, edit model.PollEdit) ([]string, map[string]string, []string, error) {
+	options := append([]string(nil), poll.Options...)
+	renamed := make(map[string]string)
+	var removed []string
+
+	for old, text := range edit.Rename {
+		option, err := resolveOption(poll, old)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		text = strings.TrimSpace(text)
+		if text == option {
+			continue
+		}
+		for i := range options {
+			if poll.Options[i] == option {
+				options[i] = text
+			}
+		}
+		renamed[option] = text
+	}
+
+	for _, input := range edit.Remove {
+		option, err := resolveOption(poll, input)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if _, ok := renamed[option]; ok {
+			return nil, nil, nil, fmt.Errorf("option %q is both renamed and removed", option)
+		}
+		for i := range options {
+			if poll.Options[i] == option && options[i] != "" {
+				options[i] = ""
+				removed = append(removed, option)
+			}
+		}
+	}
+
+	kept := options[:0]
+	for _, option := range options {
+		if option != "" {
+			kept = append(kept, option)
+		}
+	}
+
+	for _, added := range edit.Add {
+		kept = append(kept, strings.TrimSpace(added))
+	}
+
+	if len(renamed) == 0 {
+		renamed = nil
+	}
+	return kept, renamed, removed, nil
+}
+
+// validateOptionCount checks the settings that depend on the number of options of a published poll
+func validateOptionCount(settings model.PollSettings, options []string) error {
+	if settings.Type == model.PollTypeApproval && settings.MaxChoices > len(options) {
+		return fmt.Errorf("the poll's choice limit is %d, it needs at least as many options", settings.MaxChoices)
+	}
+	if settings.Type == model.PollTypeSTV && settings.Seats >= len(options) {
+		return fmt.Errorf("the poll fills %d seats, it needs at least %d options", settings.Seats, settings.Seats+1)
+	}
+	return nil
+}
+
+// dropOfferedSuggestions drops the pending suggestions that the edited options already offer,
+// there is nothing left to approve. It returns the number of dropped suggestions
+func dropOfferedSuggestions(poll *model.Poll, options []string) int {
+	pending := make([]model.Suggestion, 0, len(poll.Suggestions))
+	for _, suggestion := range poll.Suggestions {
+		if !contains(options, suggestion.Option) {
+			pending = append(pending, suggestion)
+		}
+	}
+
+	dropped := len(poll.Suggestions) - len(pending)
+	if dropped > 0 {
+		poll.Suggestions = pending
+	}
+	return dropped
+}
+
+// isRenameTarget reports whether the option is the new text of a renamed option
+func isRenameTarget(renamed map[string]string, option string) bool {
+	for _, text := range renamed {
+		if text == option {
+			return true
+		}
+	}
+	return false
+}
+
+// migrateBallots moves the votes of renamed options to their new text and clears the votes
+// of removed options. Ballots left without any vote are deleted. It returns the vote keys
+// of the ballots that lost a vote
+func migrateBallots(poll *model.Poll, renamed map[string]string, removed []string) []string {
+	rename := func(option string) string {
+		if text, ok := renamed[option]; ok {
+			return text
+		}
+		return option
+	}
+
+	var affected []string
+	for key, ballot := range poll.Votes {
+		lost := false
+
+		var choices []string
+		for _, choice := range ballot.Choices {
+			if contains(removed, choice) {
+				lost = true
+				continue
+			}
+			choices = append(choices, rename(choice))
+		}
+
+		var scores map[string]int
+		if ballot.Scores != nil {
+			scores = make(map[string]int, len(ballot.Scores))
+			for option, score := range ballot.Scores {
+				if contains(removed, option) {
+					lost = lost || score != 0
+					continue
+				}
+				scores[rename(option)] = score
+			}
+		}
+
+		ballot.Choices, ballot.Scores = choices, scores
+		if lost {
+			affected = append(affected, key)
+		}
+
+		if len(choices) == 0 && len(scores) == 0 {
+			delete(poll.Votes, key)
+			continue
+		}
+		poll.Votes[key] = ballot
+	}
+
+	if poll.TieBreak != nil {
+		for i, option := range poll.TieBreak.Tied {
+			poll.TieBreak.Tied[i] = rename(option)
+		}
+		if poll.TieBreak.Winner != "" {
+			poll.TieBreak.Winner = rename(poll.TieBreak.Winner)
+		}
+	}
+
+	return affected
+}
+
+// notifyClearedVoters DMs the voters whose votes for removed options were cleared. Ballots
+// of anonymous polls are matched to the channel's members through their vote keys
+func (s *Service) notifyClearedVoters(poll *model.Poll, keys []string, removed []string) {
+	if s.directory == nil {
+		slog.Warn("No directory set, voters of removed options not notified", "poll_id", poll.ID)
+		return
+	}
+
+	recipients := keys
+	if poll.IsAnonymous() {
+		members, err := s.directory.ChannelMembers(poll.ChannelID)
+		if err != nil {
+			slog.Error("Failed to get channel members", "poll_id", poll.ID, "error", err)
+			return
+		}
+
+		recipients = nil
+		for _, userID := range members {
+			key, err := s.voterKey(poll, userID)
+			if err != nil {
+				slog.Error("Failed to derive vote key", "poll_id", poll.ID, "error", err)
+				return
+			}
+			if contains(keys, key) {
+				recipients = append(recipients, userID)
+			}
+		}
+	}
+
+	message := fmt.Sprintf("Options removed from poll **%s**: %s. Your votes for them were cleared.\n\n"+
+		"To vote again: `/poll-vote %s \"Option\"`\nTo see the options: `/poll-results %s`",
+		poll.Title, quoteOptions(removed), poll.ID, poll.ID)
+
+	for _, userID := range recipients {
+		if err := s.directory.SendDirectMessage(userID, message); err != nil {
+			slog.Error("Failed to notify voter", "poll_id", poll.ID, "user_id", userID, "error", err)
+		}
+	}
+}
+
+// FormatEditHistory formats the poll's latest title and option changes
+func (s *Service) FormatEditHistory(ctx context.Context, pollID string) (string, error) {
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return "", ErrPollNotFound
+		}
+		return "", fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	history := fmt.Sprintf("### Edit History: %s\n\n", poll.Title)
+	if len(poll.Edits) == 0 {
+		return history + "The poll hasn't been edited.", nil
+	}
+
+	edits := poll.Edits
+	if len(edits) > editHistoryLimit {
+		history += fmt.Sprintf("_%d earlier edits not shown_\n\n", len(edits)-editHistoryLimit)
+		edits = edits[len(edits)-editHistoryLimit:]
+	}
+
+	for _, edit := range edits {
+		var changes []string
+		if edit.NewTitle != "" {
+			changes = append(changes, fmt.Sprintf("title %q → %q", edit.OldTitle, edit.NewTitle))
+		}
+		renamed := make([]string, 0, len(edit.Renamed))
+		for old := range edit.Renamed {
+			renamed = append(renamed, old)
+		}
+		sort.Strings(renamed)
+		for _, old := range renamed {
+			changes = append(changes, fmt.Sprintf("renamed %q → %q", old, edit.Renamed[old]))
+		}
+		if len(edit.Added) > 0 {
+			changes = append(changes, "added "+quoteOptions(edit.Added))
+		}
+		if len(edit.Removed) > 0 {
+			removed := "removed " + quoteOptions(edit.Removed)
+			if edit.ClearedVotes > 0 {
+				removed += fmt.Sprintf(" (%d votes cleared)", edit.ClearedVotes)
+			}
+			changes = append(changes, removed)
+		}
+		if len(changes) == 0 {
+			changes = append(changes, "no changes")
+		}
+
+		history += fmt.Sprintf("- %s: %s\n", model.FormatTime(edit.At), strings.Join(changes, ", "))
+	}
+
+	return history, nil
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+func TestApplyOptionEdit(t *testing.T) {
+	tests := []struct {
+		name    string
+		edit    model.PollEdit
+		options []string
+		renamed map[string]string
+		removed []string
+		err     string // part of the expected error
+	}{
+		{
+			name:    "rename by number",
+			edit:    model.PollEdit{Rename: map[string]string{"2": " Ramen "}},
+			options: []string{"Pizza", "Ramen", "Tacos"},
+			renamed: map[string]string{"Sushi": "Ramen"},
+		},
+		{
+			name:    "rename to the same text is skipped",
+			edit:    model.PollEdit{Rename: map[string]string{"pizza": "Pizza"}},
+			options: []string{"Pizza", "Sushi", "Tacos"},
+		},
+		{
+			name:    "remove by prefix",
+			edit:    model.PollEdit{Remove: []string{"tac"}},
+			options: []string{"Pizza", "Sushi"},
+			removed: []string{"Tacos"},
+		},
+		{
+			name:    "the same option removed twice",
+			edit:    model.PollEdit{Remove: []string{"Tacos", "3"}},
+			options: []string{"Pizza", "Sushi"},
+			removed: []string{"Tacos"},
+		},
+		{
+			name: "rename, remove and add together",
+			edit: model.PollEdit{
+				Rename: map[string]string{"Sushi": "Ramen"},
+				Remove: []string{"Pizza"},
+				Add:    []string{" Burgers "},
+			},
+			options: []string{"Ramen", "Tacos", "Burgers"},
+			renamed: map[string]string{"Sushi": "Ramen"},
+			removed: []string{"Pizza"},
+		},
+		{
+			name: "renamed and removed",
+			edit: model.PollEdit{Rename: map[string]string{"Sushi": "Ramen"}, Remove: []string{"Sushi"}},
+			err:  `option "Sushi" is both renamed and removed`,
+		},
+		{
+			name: "unknown option",
+			edit: model.PollEdit{Remove: []string{"Burgers"}},
+			err:  `invalid option: "Burgers"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := &model.Poll{Options: []string{"Pizza", "Sushi", "Tacos"}}
+
+			options, renamed, removed, err := applyOptionEdit(poll, tt.edit)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(options, tt.options) {
+				t.Errorf("options = %q, want %q", options, tt.options)
+			}
+			if !reflect.DeepEqual(renamed, tt.renamed) {
+				t.Errorf("renamed = %q, want %q", renamed, tt.renamed)
+			}
+			if !reflect.DeepEqual(removed, tt.removed) {
+				t.Errorf("removed = %q, want %q", removed, tt.removed)
+			}
+			if !reflect.DeepEqual(poll.Options, []string{"Pizza", "Sushi", "Tacos"}) {
+				t.Errorf("poll options changed to %q", poll.Options)
+			}
+		})
+	}
+}
+
+func TestMigrateBallots(t *testing.T) {
+	tests := []struct {
+		name     string
+		votes    map[string]model.Ballot
+		renamed  map[string]string
+		removed  []string
+		want     map[string]model.Ballot
+		affected []string
+	}{
+		{
+			name: "renamed choices keep their place",
+			votes: map[string]model.Ballot{
+				"u1": {Choices: []string{"Sushi", "Pizza"}, Weight: 2, Reason: "fresh"},
+			},
+			renamed: map[string]string{"Sushi": "Ramen"},
+			want: map[string]model.Ballot{
+				"u1": {Choices: []string{"Ramen", "Pizza"}, Weight: 2, Reason: "fresh"},
+			},
+		},
+		{
+			name: "removed choices are cleared, empty ballots deleted",
+			votes: map[string]model.Ballot{
+				"u1": {Choices: []string{"Tacos", "Pizza"}},
+				"u2": {Choices: []string{"Tacos"}},
+				"u3": {Choices: []string{"Sushi"}},
+			},
+			removed: []string{"Tacos"},
+			want: map[string]model.Ballot{
+				"u1": {Choices: []string{"Pizza"}},
+				"u3": {Choices: []string{"Sushi"}},
+			},
+			affected: []string{"u1", "u2"},
+		},
+		{
+			name: "scores are renamed and cleared, a zero score is no lost vote",
+			votes: map[string]model.Ballot{
+				"u1": {Scores: map[string]int{"Pizza": 5, "Tacos": 0}},
+				"u2": {Scores: map[string]int{"Tacos": 3, "Sushi": 1}},
+				"u3": {Scores: map[string]int{"Tacos": -2}},
+			},
+			renamed: map[string]string{"Sushi": "Ramen"},
+			removed: []string{"Tacos"},
+			want: map[string]model.Ballot{
+				"u1": {Scores: map[string]int{"Pizza": 5}},
+				"u2": {Scores: map[string]int{"Ramen": 1}},
+			},
+			affected: []string{"u2", "u3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poll := &model.Poll{Votes: tt.votes}
+
+			affected := migrateBallots(poll, tt.renamed, tt.removed)
+			sort.Strings(affected)
+
+			if !reflect.DeepEqual(poll.Votes, tt.want) {
+				t.Errorf("votes = %+v, want %+v", poll.Votes, tt.want)
+			}
+			if !reflect.DeepEqual(affected, tt.affected) {
+				t.Errorf("affected = %q, want %q", affected, tt.affected)
+			}
+		})
+	}
+}
+
+func TestMigrateBallotsTieBreak(t *testing.T) {
+	poll := &model.Poll{
+		TieBreak: &model.TieBreak{Policy: model.TieBreakCreator, Tied: []string{"Sushi", "Pizza"}, Winner: "Sushi"},
+	}
+
+	migrateBallots(poll, map[string]string{"Sushi": "Ramen"}, nil)
+
+	if want := []string{"Ramen", "Pizza"}; !reflect.DeepEqual(poll.TieBreak.Tied, want) {
+		t.Errorf("tied = %q, want %q", poll.TieBreak.Tied, want)
+	}
+	if poll.TieBreak.Winner != "Ramen" {
+		t.Errorf("winner = %q, want %q", poll.TieBreak.Winner, "Ramen")
+	}
+}
+
+func TestDropOfferedSuggestions(t *testing.T) {
+	poll := &model.Poll{Suggestions: []model.Suggestion{{Option: "Ramen"}, {Option: "Burgers"}}}
+
+	if dropped := dropOfferedSuggestions(poll, []string{"Pizza", "Ramen"}); dropped != 1 {
+		t.Errorf("dropped = %d, want 1", dropped)
+	}
+	if want := []model.Suggestion{{Option: "Burgers"}}; !reflect.DeepEqual(poll.Suggestions, want) {
+		t.Errorf("suggestions = %+v, want %+v", poll.Suggestions, want)
+	}
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hard-gainer/voting-bot/internal/db"
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// startPoll opens the poll at the given unix time. Polls with a duration get their closing time here
+func startPoll(poll *model.Poll, now uint64) {
+	poll.Status = model.StatusOpen
+	poll.OpensAt = now
+	if poll.Settings.Duration > 0 {
+		poll.ClosesAt = now + poll.Settings.Duration
+	}
+}
+
+// getDraft returns a draft or scheduled poll the user owns
+func (s *Service) getDraft(ctx context.Context, pollID, userID string) (*model.Poll, error) {
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrPollNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if err := authorize(poll, userID, "change draft"); err != nil {
+		return nil, err
+	}
+
+	if !poll.IsDraft() && !poll.IsScheduled() {
+		return nil, ErrNotDraft
+	}
+
+	return poll, nil
+}
+
+// PublishPoll opens a draft or scheduled poll right away
+func (s *Service) PublishPoll(ctx context.Context, pollID, userID string) (*model.Poll, error) {
+	slog.Info("Publishing poll", "poll_id", pollID, "user_id", userID)
+
+	poll, err := s.getDraft(ctx, pollID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.openPoll(ctx, poll); err != nil {
+		return nil, err
+	}
+	return poll, nil
+}
+
+// SchedulePoll schedules a draft to open at the given unix time, scheduled polls are rescheduled
+func (s *Service) SchedulePoll(ctx context.Context, pollID string, opensAt uint64, userID string) (*model.Poll, error) {
+	slog.Info("Scheduling poll", "poll_id", pollID, "user_id", userID, "opens_at", opensAt)
+
+	poll, err := s.getDraft(ctx, pollID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opensAt <= uint64(time.Now().Unix()) {
+		return nil, errors.New("opening time must be in the future")
+	}
+	if poll.ClosesAt != 0 && poll.ClosesAt <= opensAt {
+		return nil, errors.New("the poll would close before it opens")
+	}
+
+	poll.Status = model.StatusScheduled
+	poll.OpensAt = opensAt
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to schedule poll", "poll_id", pollID, "error", err)
+		return nil, fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	slog.Info("Poll scheduled", "poll_id", pollID, "opens_at", opensAt)
+	return poll, nil
+}
+
+// openPoll opens the poll. Drafts published by their owners and scheduled polls
+// opened by the scheduler both go through here
+func (s *Service) openPoll(ctx context.Context, poll *model.Poll) error {
+	now := uint64(time.Now().Unix())
+	if poll.ClosesAt != 0 && poll.Settings.Duration == 0 && poll.ClosesAt <= now {
+		return ErrInvalidDeadline
+	}
+
+	startPoll(poll, now)
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to open poll", "poll_id", poll.ID, "error", err)
+		return fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	slog.Info("Poll opened", "poll_id", poll.ID, "closes_at", poll.ClosesAt)
+	return nil
+}
+
+// ListDrafts returns the drafts and scheduled polls the user owns
+func (s *Service) ListDrafts(ctx context.Context, userID string) ([]*model.Poll, error) {
+	slog.Info("Listing drafts", "user_id", userID)
+
+	allPolls, err := s.storage.ListPolls(ctx)
+	if err != nil {
+		slog.Error("Failed to list polls", "error", err)
+		return nil, fmt.Errorf("failed to list polls: %w", err)
+	}
+
+	drafts := make([]*model.Poll, 0)
+	for _, poll := range allPolls {
+		if poll.IsOwner(userID) && (poll.IsDraft() || poll.IsScheduled()) {
+			drafts = append(drafts, poll)
+		}
+	}
+
+	return drafts, nil
+}
+
+// openScheduledPolls opens the polls whose opening time has passed and announces them
+// in the channels they were created in
+func (s *Service) openScheduledPolls(ctx context.Context) {
+	polls, err := s.storage.ListPollsToOpen(ctx, uint64(time.Now().Unix()))
+	if err != nil {
+		slog.Error("Failed to list scheduled polls", "error", err)
+		return
+	}
+
+	for _, poll := range polls {
+		slog.Info("Opening scheduled poll", "poll_id", poll.ID, "opens_at", poll.OpensAt)
+
+		if err := s.openPoll(ctx, poll); err != nil {
+			if errors.Is(err, ErrInvalidDeadline) {
+				// retrying would never succeed, the owners have to decide when it runs
+				s.unschedulePoll(ctx, poll)
+				continue
+			}
+			slog.Error("Failed to open scheduled poll", "poll_id", poll.ID, "error", err)
+			continue
+		}
+
+		if poll.ChannelID == "" {
+			slog.Warn("Opened poll has no channel, announcement not posted", "poll_id", poll.ID)
+			continue
+		}
+
+		if err := s.NotifyChannel(poll.ChannelID, formatOpening(poll)); err != nil {
+			slog.Error("Failed to announce opened poll", "poll_id", poll.ID, "error", err)
+		}
+	}
+}
+
+// unschedulePoll moves a scheduled poll whose fixed closing time passed before it opened
+// back to the drafts and tells its owners. Like a clone, the poll keeps the time it was
+// meant to run for and gets a new closing time when it opens
+func (s *Service) unschedulePoll(ctx context.Context, poll *model.Poll) {
+	opensAt, closesAt := poll.OpensAt, poll.ClosesAt
+
+	poll.Status = model.StatusDraft
+	if closesAt > opensAt {
+		poll.Settings.Duration = closesAt - opensAt
+	}
+	poll.ClosesAt = 0
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to move scheduled poll back to drafts", "poll_id", poll.ID, "error", err)
+		return
+	}
+
+	slog.Warn("Scheduled poll missed its closing time, moved back to drafts", "poll_id", poll.ID,
+		"opens_at", opensAt, "closes_at", closesAt)
+
+	message := fmt.Sprintf("Poll **%s** (ID: `%s`) was scheduled to open at %s, but its closing time %s "+
+		"had already passed, so it was moved back to your drafts. ",
+		poll.Title, poll.ID, model.FormatTime(opensAt), model.FormatTime(closesAt))
+	if poll.Settings.Duration > 0 {
+		message += fmt.Sprintf("It will run for %s and close at the end of it.", time.Duration(poll.Settings.Duration)*time.Second)
+	} else {
+		message += "It no longer has a closing time."
+	}
+	message += fmt.Sprintf("\n\nTo open it now: `/poll-publish %s`\nTo open it later: `/poll-schedule %s TIME`", poll.ID, poll.ID)
+
+	s.notifyOwners(poll, message)
+}
+
+// formatOpening announces a poll that has just opened
+func formatOpening(poll *model.Poll) string {
+	message := fmt.Sprintf("### Poll Opened: %s\n\n**ID:** %s\n\n**Options:**\n", poll.Title, poll.ID)
+	for i, option := range poll.Options {
+		message += fmt.Sprintf("%d. %s\n", i+1, option)
+	}
+
+	if poll.ClosesAt != 0 {
+		message += fmt.Sprintf("\n**Closes at:** %s\n", model.FormatTime(poll.ClosesAt))
+	}
+
+	message += fmt.Sprintf("\nTo vote: `/poll-vote %s \"Option\"`\nTo see results: `/poll-results %s`", poll.ID, poll.ID)
+	return message
+}
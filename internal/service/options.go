@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// emojiShortcode matches Mattermost emoji shortcodes like :pizza:
+var emojiShortcode = regexp.MustCompile(`^:[a-z0-9_+-]+:$`)
+
+// resolveOption finds the poll option the voter meant. The input is matched, in order, by
+// exact text, by option number, by case-insensitive text, by the option's leading emoji
+// and by a unique case-insensitive prefix
+func resolveOption(poll *model.Poll, input string) (string, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return "", fmt.Errorf("%w: empty option", ErrInvalidOption)
+	}
+
+	for _, option := range poll.Options {
+		if option == input {
+			return option, nil
+		}
+	}
+
+	if number, err := strconv.Atoi(input); err == nil {
+		if number < 1 || number > len(poll.Options) {
+			return "", fmt.Errorf("%w: there is no option number %d", ErrInvalidOption, number)
+		}
+		return poll.Options[number-1], nil
+	}
+
+	matchers := []func(option string) bool{
+		func(option string) bool { return strings.EqualFold(option, input) },
+		func(option string) bool { return strings.EqualFold(optionEmoji(option), input) },
+		func(option string) bool { return hasPrefixFold(option, input) },
+	}
+
+	for _, matches := range matchers {
+		var candidates []string
+		for _, option := range poll.Options {
+			if matches(option) {
+				candidates = append(candidates, option)
+			}
+		}
+
+		switch len(candidates) {
+		case 0:
+			continue
+		case 1:
+			return candidates[0], nil
+		default:
+			return "", fmt.Errorf("%w: %q matches %s", ErrAmbiguousOption, input, quoteOptions(candidates))
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrInvalidOption, input)
+}
+
+// optionEmoji returns the emoji the option starts with, either a shortcode or an emoji character
+func optionEmoji(option string) string {
+	fields := strings.Fields(option)
+	if len(fields) < 2 {
+		return ""
+	}
+
+	first := fields[0]
+	if emojiShortcode.MatchString(first) {
+		return first
+	}
+
+	r, _ := utf8.DecodeRuneInString(first)
+	if unicode.Is(unicode.So, r) {
+		return first
+	}
+	return ""
+}
+
+// hasPrefixFold reports whether the option starts with the prefix ignoring case
+func hasPrefixFold(option, prefix string) bool {
+	return strings.HasPrefix(strings.ToLower(option), strings.ToLower(prefix))
+}
+
+// quoteOptions formats options for error messages
+func quoteOptions(options []string) string {
+	quoted := make([]string, len(options))
+	for i, option := range options {
+		quoted[i] = strconv.Quote(option)
+	}
+	return strings.Join(quoted, ", ")
+}
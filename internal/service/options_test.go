@@ -0,0 +1,49 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+func TestResolveOption(t *testing.T) {
+	poll := &model.Poll{Options: []string{":pizza: Pizza", "🍣 Sushi", "Salad", "Salsa", "42", "Pasta"}}
+
+	tests := []struct {
+		input string
+		want  string
+		err   string // part of the expected error
+	}{
+		{input: "Salad", want: "Salad"},
+		{input: " Salad ", want: "Salad"},
+		{input: "42", want: "42"},
+		{input: "2", want: "🍣 Sushi"},
+		{input: "7", err: "there is no option number 7"},
+		{input: "salad", want: "Salad"},
+		{input: ":PIZZA:", want: ":pizza: Pizza"},
+		{input: "🍣", want: "🍣 Sushi"},
+		{input: "pas", want: "Pasta"},
+		{input: "sal", err: `ambiguous option: "sal" matches "Salad", "Salsa"`},
+		{input: "Burgers", err: `invalid option: "Burgers"`},
+		{input: " ", err: "invalid option: empty option"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := resolveOption(poll, tt.input)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("resolveOption(%q) err = %v, want %q", tt.input, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveOption(%q): %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveOption(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
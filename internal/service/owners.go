@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/hard-gainer/voting-bot/internal/db"
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// authorize checks that the user owns the poll. Every action restricted to the poll's owners
+// goes through here, the action is only logged
+func authorize(poll *model.Poll, userID, action string) error {
+	return authorizeOwners(poll.OwnerIDs(), userID, action, "poll_id", poll.ID)
+}
+
+// authorizeOwners checks that the user is one of the owners. Polls, series and templates
+// are all checked here, the attributes identify the owned object in the log
+func authorizeOwners(owners []string, userID, action string, attrs ...any) error {
+	for _, owner := range owners {
+		if owner == userID {
+			return nil
+		}
+	}
+
+	slog.Info("Unauthorized attempt to "+action, append(attrs, "owners", owners, "requester", userID)...)
+	return ErrNotAuthorized
+}
+
+// AddOwner makes another user an owner of the poll, with the same rights as the existing owners
+func (s *Service) AddOwner(ctx context.Context, pollID, ownerID, userID string) (*model.Poll, error) {
+	slog.Info("Adding poll owner", "poll_id", pollID, "owner", ownerID, "user_id", userID)
+
+	return s.changeOwners(ctx, pollID, userID, "add owner to", func(poll *model.Poll) error {
+		if poll.IsOwner(ownerID) {
+			return errors.New("user already owns this poll")
+		}
+		poll.Owners = append(poll.OwnerIDs(), ownerID)
+		return nil
+	})
+}
+
+// RemoveOwner takes the poll away from one of its owners. Owners can remove themselves,
+// the last owner can't be removed
+func (s *Service) RemoveOwner(ctx context.Context, pollID, ownerID, userID string) (*model.Poll, error) {
+	slog.Info("Removing poll owner", "poll_id", pollID, "owner", ownerID, "user_id", userID)
+
+	return s.changeOwners(ctx, pollID, userID, "remove owner from", func(poll *model.Poll) error {
+		if !poll.IsOwner(ownerID) {
+			return errors.New("user doesn't own this poll")
+		}
+		return dropOwner(poll, ownerID)
+	})
+}
+
+// TransferOwnership hands the user's ownership of the poll over to another user.
+// Other co-owners keep theirs
+func (s *Service) TransferOwnership(ctx context.Context, pollID, ownerID, userID string) (*model.Poll, error) {
+	slog.Info("Transferring poll ownership", "poll_id", pollID, "owner", ownerID, "user_id", userID)
+
+	return s.changeOwners(ctx, pollID, userID, "transfer ownership of", func(poll *model.Poll) error {
+		if ownerID == userID {
+			return errors.New("you already own this poll")
+		}
+		if !poll.IsOwner(ownerID) {
+			poll.Owners = append(poll.OwnerIDs(), ownerID)
+		}
+		return dropOwner(poll, userID)
+	})
+}
+
+// changeOwners applies the change to the owners of the poll if the user owns it
+func (s *Service) changeOwners(ctx context.Context, pollID, userID, action string, change func(poll *model.Poll) error) (*model.Poll, error) {
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrPollNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if err := authorize(poll, userID, action+" poll"); err != nil {
+		return nil, err
+	}
+
+	if err := change(poll); err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to update poll owners", "poll_id", pollID, "error", err)
+		return nil, fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	slog.Info("Poll owners changed", "poll_id", pollID, "owners", poll.Owners)
+	return poll, nil
+}
+
+// notifyOwners sends the message to every owner of the poll by DM
+func (s *Service) notifyOwners(poll *model.Poll, message string) {
+	if s.directory == nil {
+		slog.Warn("No directory set, poll owners not notified", "poll_id", poll.ID)
+		return
+	}
+
+	for _, owner := range poll.OwnerIDs() {
+		if err := s.directory.SendDirectMessage(owner, message); err != nil {
+			slog.Error("Failed to notify poll owner", "poll_id", poll.ID, "user_id", owner, "error", err)
+		}
+	}
+}
+
+// dropOwner removes the user from the poll's owners
+func dropOwner(poll *model.Poll, ownerID string) error {
+	owners := make([]string, 0, len(poll.OwnerIDs()))
+	for _, owner := range poll.OwnerIDs() {
+		if owner != ownerID {
+			owners = append(owners, owner)
+		}
+	}
+
+	if len(owners) == 0 {
+		return ErrLastOwner
+	}
+	poll.Owners = owners
+	return nil
+}
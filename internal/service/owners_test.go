@@ -0,0 +1,37 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+func TestAuthorizeOwners(t *testing.T) {
+	poll := &model.Poll{ID: "poll1", CreatedBy: "creator", Owners: []string{"creator", "co-owner"}}
+	series := &model.Series{ID: "series1", CreatedBy: "creator"}
+	template := &model.Template{Name: "standup", CreatedBy: "creator"}
+
+	tests := []struct {
+		name   string
+		owners []string
+		userID string
+		err    error
+	}{
+		{name: "poll creator", owners: poll.OwnerIDs(), userID: "creator"},
+		{name: "poll co-owner", owners: poll.OwnerIDs(), userID: "co-owner"},
+		{name: "poll stranger", owners: poll.OwnerIDs(), userID: "someone", err: ErrNotAuthorized},
+		{name: "series creator", owners: series.OwnerIDs(), userID: "creator"},
+		{name: "series stranger", owners: series.OwnerIDs(), userID: "someone", err: ErrNotAuthorized},
+		{name: "template creator", owners: template.OwnerIDs(), userID: "creator"},
+		{name: "template stranger", owners: template.OwnerIDs(), userID: "someone", err: ErrNotAuthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := authorizeOwners(tt.owners, tt.userID, "test"); !errors.Is(err, tt.err) {
+				t.Errorf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// SetDirectory sets the directory used to reach channel members
+func (s *Service) SetDirectory(directory Directory) {
+	s.directory = directory
+}
+
+// SetRemindersOptOut stops or resumes reminder DMs for the user
+func (s *Service) SetRemindersOptOut(ctx context.Context, userID string, optOut bool) error {
+	slog.Info("Updating reminder opt-out", "user_id", userID, "opt_out", optOut)
+
+	if err := s.storage.SetRemindersOptOut(ctx, userID, optOut); err != nil {
+		slog.Error("Failed to update reminder opt-out", "user_id", userID, "error", err)
+		return fmt.Errorf("failed to update reminder settings: %w", err)
+	}
+	return nil
+}
+
+// normalizeReminders validates reminder offsets and sorts them from the earliest reminder to the latest
+func normalizeReminders(reminders []uint64) ([]uint64, error) {
+	if len(reminders) == 0 {
+		return nil, nil
+	}
+
+	maxOffset := uint64(model.MaxReminderOffset / time.Second)
+	seen := make(map[uint64]bool, len(reminders))
+	result := make([]uint64, 0, len(reminders))
+	for _, offset := range reminders {
+		if offset == 0 || offset > maxOffset {
+			return nil, fmt.Errorf("reminders can be sent from 1 second to %s before the closing time", model.MaxReminderOffset)
+		}
+		if !seen[offset] {
+			seen[offset] = true
+			result = append(result, offset)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i] > result[j] })
+	return result, nil
+}
+
+// sendReminders sends the due reminders of polls closing soon. Reminders that became due
+// at the same time are sent as one message
+func (s *Service) sendReminders(ctx context.Context) {
+	if s.directory == nil {
+		return
+	}
+
+	now := uint64(time.Now().Unix())
+	polls, err := s.storage.ListClosingPolls(ctx, now, now+uint64(model.MaxReminderOffset/time.Second))
+	if err != nil {
+		slog.Error("Failed to list closing polls", "error", err)
+		return
+	}
+
+	for _, poll := range polls {
+		due := poll.DueReminders(now)
+		if len(due) == 0 {
+			continue
+		}
+
+		// mark the reminders as sent first, so a failure doesn't lead to repeated DMs
+		poll.Reminded = append(poll.Reminded, due...)
+		if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+			slog.Error("Failed to record sent reminders", "poll_id", poll.ID, "error", err)
+			continue
+		}
+
+		if poll.ChannelID == "" {
+			slog.Warn("Poll has no channel, reminders not sent", "poll_id", poll.ID)
+			continue
+		}
+
+		sent, err := s.remindNonVoters(ctx, poll)
+		if err != nil {
+			slog.Error("Failed to send reminders", "poll_id", poll.ID, "error", err)
+			continue
+		}
+
+		slog.Info("Reminders sent", "poll_id", poll.ID, "recipients", sent)
+	}
+}
+
+// remindNonVoters DMs the channel members who haven't voted in the poll and haven't opted out.
+// It returns the number of reminders sent
+func (s *Service) remindNonVoters(ctx context.Context, poll *model.Poll) (int, error) {
+	members, err := s.directory.ChannelMembers(poll.ChannelID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get channel members: %w", err)
+	}
+
+	message := formatReminder(poll)
+	sent := 0
+	for _, userID := range members {
+		key, err := s.voterKey(poll, userID)
+		if err != nil {
+			return sent, err
+		}
+		if _, voted := poll.Votes[key]; voted {
+			continue
+		}
+
+		optOut, err := s.storage.IsRemindersOptOut(ctx, userID)
+		if err != nil {
+			slog.Error("Failed to check reminder opt-out", "user_id", userID, "error", err)
+			continue
+		}
+		if optOut {
+			continue
+		}
+
+		if err := s.directory.SendDirectMessage(userID, message); err != nil {
+			slog.Error("Failed to send reminder", "poll_id", poll.ID, "user_id", userID, "error", err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// formatReminder asks a member to vote before the poll closes
+func formatReminder(poll *model.Poll) string {
+	return fmt.Sprintf("Reminder: you haven't voted in poll **%s** yet, it closes at %s.\n\n"+
+		"To vote: `/poll-vote %s \"Option\"`\nTo see the options: `/poll-results %s`\n"+
+		"To stop these reminders: `/poll-reminders off`",
+		poll.Title, model.FormatTime(poll.ClosesAt), poll.ID, poll.ID)
+}
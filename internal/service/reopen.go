@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hard-gainer/voting-bot/internal/db"
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// historyEventsLimit is the number of latest lifecycle events shown with the results
+const historyEventsLimit = 10
+
+// ReopenPoll reopens a closed poll keeping its votes. A positive duration in seconds
+// sets a new closing time, otherwise the poll stays open until it is ended
+func (s *Service) ReopenPoll(ctx context.Context, pollID string, duration uint64, userID string) (*model.Poll, error) {
+	slog.Info("Reopening poll", "poll_id", pollID, "user_id", userID, "duration", duration)
+
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrPollNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if err := authorize(poll, userID, "reopen poll"); err != nil {
+		return nil, err
+	}
+
+	if !poll.IsClosed() {
+		return nil, ErrNotClosed
+	}
+
+	if poll.TieBreak != nil && poll.TieBreak.RunoffID != "" {
+		return nil, fmt.Errorf("a runoff poll %s was already created for this poll's tie", poll.TieBreak.RunoffID)
+	}
+
+	now := uint64(time.Now().Unix())
+
+	poll.Status = model.StatusOpen
+	poll.ClosesAt = 0
+	if duration > 0 {
+		poll.ClosesAt = now + duration
+	}
+	// the tie is resolved again when the poll closes, reminders start over for the new closing time
+	poll.TieBreak = nil
+	poll.Reminded = nil
+	poll.Events = append(poll.Events, model.PollEvent{
+		Type:  model.EventReopened,
+		At:    now,
+		By:    userID,
+		Until: poll.ClosesAt,
+	})
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to reopen poll", "poll_id", pollID, "error", err)
+		return nil, fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	slog.Info("Poll reopened", "poll_id", pollID, "closes_at", poll.ClosesAt)
+	return poll, nil
+}
+
+// ExtendPoll moves the closing time of an open poll later by the duration in seconds
+func (s *Service) ExtendPoll(ctx context.Context, pollID string, duration uint64, userID string) (*model.Poll, error) {
+	slog.Info("Extending poll", "poll_id", pollID, "user_id", userID, "duration", duration)
+
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrPollNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if err := authorize(poll, userID, "extend poll"); err != nil {
+		return nil, err
+	}
+
+	if err := checkOpen(poll); err != nil {
+		return nil, err
+	}
+
+	if poll.ClosesAt == 0 {
+		return nil, ErrNoDeadline
+	}
+
+	if duration == 0 {
+		return nil, errors.New("extension must be positive")
+	}
+
+	now := uint64(time.Now().Unix())
+	poll.ClosesAt += duration
+
+	// reminders that are no longer due for the later closing time are sent again
+	reminded := poll.Reminded[:0]
+	for _, offset := range poll.Reminded {
+		if now+offset >= poll.ClosesAt {
+			reminded = append(reminded, offset)
+		}
+	}
+	poll.Reminded = reminded
+
+	poll.Events = append(poll.Events, model.PollEvent{
+		Type:  model.EventExtended,
+		At:    now,
+		By:    userID,
+		Until: poll.ClosesAt,
+	})
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to extend poll", "poll_id", pollID, "error", err)
+		return nil, fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	slog.Info("Poll extended", "poll_id", pollID, "closes_at", poll.ClosesAt)
+	return poll, nil
+}
+
+// formatHistory lists the latest close, reopen and extend events of a reopened poll
+func formatHistory(poll *model.Poll) string {
+	if !poll.WasReopened() {
+		return ""
+	}
+
+	events := poll.Events
+	if len(events) > historyEventsLimit {
+		events = events[len(events)-historyEventsLimit:]
+	}
+
+	history := "_This poll was reopened after being closed, votes cast before closing were kept._\n\n**History:**\n"
+	for _, event := range events {
+		line := fmt.Sprintf("- %s: %s", model.FormatTime(event.At), event.Type)
+		switch {
+		case event.Type == model.EventClosed && event.By == "":
+			line += " automatically"
+		case event.Type != model.EventClosed && event.Until != 0:
+			line += ", closes at " + model.FormatTime(event.Until)
+		case event.Type == model.EventReopened:
+			line += " without a closing time"
+		}
+		history += line + "\n"
+	}
+
+	return history + "\n"
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+	"github.com/hard-gainer/voting-bot/internal/tally"
+)
+
+// formatCountedResults formats the results of a poll counted by a tally method
+func (s *Service) formatCountedResults(poll *model.Poll) (string, error) {
+	counter, err := counterFor(poll)
+	if err != nil {
+		return "", err
+	}
+
+	ballots := make([]model.Ballot, 0, len(poll.Votes))
+	for _, ballot := range poll.Votes {
+		ballots = append(ballots, ballot)
+	}
+
+	formattedResults := fmt.Sprintf("### Poll: %s\n\n", poll.Title)
+
+	formattedResults += formatStatus(poll)
+
+	if poll.IsAnonymous() {
+		formattedResults += "_Anonymous poll: voters are not recorded._\n\n"
+	}
+
+	formattedResults += fmt.Sprintf("**Method: %s**\n\n", counter.Name())
+	formattedResults += fmt.Sprintf("**Total ballots: %d**\n\n", len(ballots))
+	if poll.IsQuadratic() {
+		formattedResults += fmt.Sprintf("**Credit budget: %d per voter**\n\n", poll.Settings.Credits)
+	}
+	if poll.IsWeighted() {
+		formattedResults += fmt.Sprintf("**Total weight: %.2f**\n\n", poll.TotalWeight())
+	}
+
+	// STV elections are counted once the poll is closed
+	if poll.IsSTV() && !poll.IsClosed() {
+		formattedResults += fmt.Sprintf("Electing %d of %d options. The count will run when the poll is closed.\n",
+			poll.Settings.Seats, len(poll.Options))
+		return formattedResults, nil
+	}
+
+	result := counter.Count(poll.Options, ballots)
+
+	formattedResults += "#### Results:\n"
+	formattedResults += result.Report + "\n"
+	if poll.IsSTV() {
+		formattedResults += formatElected(result.Winners, len(ballots))
+	} else {
+		formattedResults += formatWinners(result.Winners, len(ballots))
+	}
+
+	if poll.TieBreak != nil {
+		formattedResults += formatTieBreak(poll)
+	}
+
+	formattedResults += formatReasons(poll)
+
+	slog.Info("Results formatted successfully", "poll_id", poll.ID, "method", poll.Settings.Method)
+	return formattedResults, nil
+}
+
+// formatReasons lists the voters' reasons grouped by the options they support.
+// Reasons are sorted by text and never attributed, so they can't be traced back to voters
+func formatReasons(poll *model.Poll) string {
+	reasons := make(map[string][]string, len(poll.Options))
+	for _, ballot := range poll.Votes {
+		if ballot.Reason == "" {
+			continue
+		}
+		for _, option := range supportedOptions(poll, ballot) {
+			reasons[option] = append(reasons[option], ballot.Reason)
+		}
+	}
+
+	if len(reasons) == 0 {
+		return ""
+	}
+
+	formatted := "\n#### Reasons:\n"
+	if poll.IsRanked() {
+		formatted = "\n#### Reasons (by first preference):\n"
+	}
+	for _, option := range poll.Options {
+		if len(reasons[option]) == 0 {
+			continue
+		}
+		sort.Strings(reasons[option])
+		formatted += fmt.Sprintf("- **%s**\n", option)
+		for _, reason := range reasons[option] {
+			formatted += fmt.Sprintf("  - \"%s\"\n", reason)
+		}
+	}
+	return formatted
+}
+
+// formatStatus describes the lifecycle state of the poll and its closing time
+func formatStatus(poll *model.Poll) string {
+	status := ""
+	switch {
+	case poll.IsClosed():
+		status = "**Status: Closed**\n\n"
+	case poll.IsDraft():
+		status = "**Status: Draft**, not open for voting yet\n\n"
+	case poll.IsScheduled():
+		status = fmt.Sprintf("**Status: Scheduled**, opens at %s\n\n", model.FormatTime(poll.OpensAt))
+	case poll.IsTrashed():
+		status = fmt.Sprintf("**Status: Deleted**, an owner can restore it with `/poll-restore %s`\n\n", poll.ID)
+	case poll.ClosesAt != 0:
+		status = fmt.Sprintf("**Closes at: %s**\n\n", model.FormatTime(poll.ClosesAt))
+	}
+
+	status += formatHistory(poll)
+
+	if poll.ClonedFrom != "" {
+		status += fmt.Sprintf("_Re-run of poll `%s`, compare: `/poll-results %s --compare`_\n\n", poll.ClonedFrom, poll.ID)
+	}
+
+	if poll.IsOpen() || poll.IsClosed() {
+		if edits := len(poll.Edits); edits > 0 {
+			status += fmt.Sprintf("_Edited %d time(s) since it was created, see `/poll-edit %s --history`_\n\n", edits, poll.ID)
+		}
+	}
+
+	if poll.SeriesID != "" {
+		status += fmt.Sprintf("_Recurring poll, earlier results: `/poll-recur history %s`_\n\n", poll.SeriesID)
+	}
+	return status
+}
+
+// counterFor returns the counter for the poll's tally method
+func counterFor(poll *model.Poll) (tally.Counter, error) {
+	if poll.IsSTV() {
+		return tally.STV{Seats: poll.Settings.Seats}, nil
+	}
+
+	counter, ok := tally.Get(poll.Settings.Method)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMethod, poll.Settings.Method)
+	}
+	return counter, nil
+}
+
+// formatElected formats the list of elected options
+func formatElected(elected []string, ballots int) string {
+	if ballots == 0 || len(elected) == 0 {
+		return "No votes cast, nobody was elected.\n"
+	}
+	return fmt.Sprintf("**Elected: %s**\n", strings.Join(elected, ", "))
+}
+
+// formatWinners formats the winner line of the results
+func formatWinners(winners []string, ballots int) string {
+	switch {
+	case ballots == 0 || len(winners) == 0:
+		return "No votes yet.\n"
+	case len(winners) == 1:
+		return fmt.Sprintf("**Winner: %s**\n", winners[0])
+	default:
+		return fmt.Sprintf("**Tie between: %s**\n", strings.Join(winners, ", "))
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunScheduler runs recurring series, opens scheduled polls, closes overdue polls, sends reminders
+// and purges expired trash every interval until the context is cancelled
+func (s *Service) RunScheduler(ctx context.Context, interval time.Duration) {
+	slog.Info("Starting poll scheduler", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Poll scheduler stopped")
+			return
+		case <-ticker.C:
+			s.runDueSeries(ctx)
+			s.openScheduledPolls(ctx)
+			s.closeOverduePolls(ctx)
+			s.sendReminders(ctx)
+			s.purgeTrash(ctx)
+		}
+	}
+}
+
+// closeOverduePolls closes the polls whose closing time has passed and posts
+// their final results to the channels they were created in
+func (s *Service) closeOverduePolls(ctx context.Context) {
+	polls, err := s.storage.ListDuePolls(ctx, uint64(time.Now().Unix()))
+	if err != nil {
+		slog.Error("Failed to list overdue polls", "error", err)
+		return
+	}
+
+	for _, poll := range polls {
+		slog.Info("Closing overdue poll", "poll_id", poll.ID, "closes_at", poll.ClosesAt)
+
+		if err := s.closePoll(ctx, poll, ""); err != nil {
+			slog.Error("Failed to close overdue poll", "poll_id", poll.ID, "error", err)
+			continue
+		}
+
+		if poll.ChannelID == "" {
+			slog.Warn("Closed poll has no channel, results not posted", "poll_id", poll.ID)
+			continue
+		}
+
+		results, err := s.FormatPollResults(ctx, poll.ID)
+		if err != nil {
+			slog.Error("Failed to format results of closed poll", "poll_id", poll.ID, "error", err)
+			continue
+		}
+
+		message := "Poll has been closed automatically: its closing time has passed.\n\n" + results
+		if err := s.NotifyChannel(poll.ChannelID, message); err != nil {
+			slog.Error("Failed to post results of closed poll", "poll_id", poll.ID, "error", err)
+		}
+	}
+}
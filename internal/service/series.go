@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hard-gainer/voting-bot/internal/cron"
+	"github.com/hard-gainer/voting-bot/internal/db"
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// historyLimit is the number of latest instances shown in a series' history
+const historyLimit = 10
+
+// CreateSeries stores a recurring poll definition. A fresh poll is opened in the channel
+// every time the schedule fires, the previous one is closed at the same time
+func (s *Service) CreateSeries(ctx context.Context, schedule, title string, options []string, creatorID, channelID string, settings model.PollSettings) (*model.Series, error) {
+	slog.Info("Creating series", "title", title, "schedule", schedule, "creator", creatorID)
+
+	parsed, err := cron.Parse(schedule)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.VotePolicy == model.VotePolicyWindow {
+		return nil, errors.New("recurring polls can't have a vote change cutoff, use --lock-votes or the default policy")
+	}
+
+	// the definition is validated the same way as the polls it opens
+	poll, err := s.newPoll(title, options, creatorID, channelID, 0, settings, model.StatusDraft)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	next := parsed.Next(now)
+	if next.IsZero() {
+		return nil, fmt.Errorf("schedule %q never fires", schedule)
+	}
+
+	series := &model.Series{
+		ID:        uuid.New().String(),
+		Title:     poll.Title,
+		Options:   poll.Options,
+		Settings:  poll.Settings,
+		CreatedBy: creatorID,
+		CreatedAt: uint64(now.Unix()),
+		ChannelID: channelID,
+		Schedule:  strings.TrimSpace(schedule),
+		NextRunAt: uint64(next.Unix()),
+	}
+
+	if err := s.storage.CreateSeries(ctx, series); err != nil {
+		slog.Error("Failed to create series", "error", err)
+		return nil, fmt.Errorf("failed to create series: %w", err)
+	}
+
+	slog.Info("Series created", "series_id", series.ID, "next_run_at", series.NextRunAt)
+	return series, nil
+}
+
+// getSeries returns the series by ID
+func (s *Service) getSeries(ctx context.Context, seriesID string) (*model.Series, error) {
+	series, err := s.storage.GetSeries(ctx, seriesID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		return nil, fmt.Errorf("failed to get series: %w", err)
+	}
+	return series, nil
+}
+
+// StopSeries stops a series from opening new polls. Its polls and history are kept
+func (s *Service) StopSeries(ctx context.Context, seriesID, userID string) (*model.Series, error) {
+	slog.Info("Stopping series", "series_id", seriesID, "user_id", userID)
+
+	series, err := s.getSeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeOwners(series.OwnerIDs(), userID, "stop series", "series_id", seriesID); err != nil {
+		return nil, err
+	}
+
+	series.NextRunAt = 0
+	if err := s.storage.UpdateSeries(ctx, series); err != nil {
+		slog.Error("Failed to stop series", "series_id", seriesID, "error", err)
+		return nil, fmt.Errorf("failed to update series: %w", err)
+	}
+
+	return series, nil
+}
+
+// ListSeries returns the series posting to the channel
+func (s *Service) ListSeries(ctx context.Context, channelID string) ([]*model.Series, error) {
+	all, err := s.storage.ListSeries(ctx)
+	if err != nil {
+		slog.Error("Failed to list series", "error", err)
+		return nil, fmt.Errorf("failed to list series: %w", err)
+	}
+
+	series := make([]*model.Series, 0)
+	for _, item := range all {
+		if item.ChannelID == channelID {
+			series = append(series, item)
+		}
+	}
+	return series, nil
+}
+
+// runDueSeries opens the next poll of every series whose schedule has fired
+func (s *Service) runDueSeries(ctx context.Context) {
+	now := time.Now()
+
+	due, err := s.storage.ListDueSeries(ctx, uint64(now.Unix()))
+	if err != nil {
+		slog.Error("Failed to list due series", "error", err)
+		return
+	}
+
+	for _, series := range due {
+		if err := s.runSeries(ctx, series, now); err != nil {
+			slog.Error("Failed to run series", "series_id", series.ID, "error", err)
+		}
+	}
+}
+
+// runSeries closes the series' previous poll, posting its results, and opens the next one
+func (s *Service) runSeries(ctx context.Context, series *model.Series, now time.Time) error {
+	slog.Info("Running series", "series_id", series.ID, "next_run_at", series.NextRunAt)
+
+	if previousID := series.LatestPollID(); previousID != "" {
+		s.closeSeriesPoll(ctx, previousID)
+	}
+
+	// the next run is set even if the poll can't be opened, so a broken definition doesn't retry on every tick
+	series.NextRunAt = 0
+	if parsed, err := cron.Parse(series.Schedule); err == nil {
+		if next := parsed.Next(now); !next.IsZero() {
+			series.NextRunAt = uint64(next.Unix())
+		}
+	}
+
+	poll, err := s.newPoll(series.Title, series.Options, series.CreatedBy, series.ChannelID, 0, series.Settings, model.StatusOpen)
+	if err != nil {
+		if updateErr := s.storage.UpdateSeries(ctx, series); updateErr != nil {
+			slog.Error("Failed to update series", "series_id", series.ID, "error", updateErr)
+		}
+		return err
+	}
+	poll.SeriesID = series.ID
+
+	if err := s.storage.CreatePoll(ctx, poll); err != nil {
+		return fmt.Errorf("failed to create poll: %w", err)
+	}
+
+	series.PollIDs = append(series.PollIDs, poll.ID)
+	if err := s.storage.UpdateSeries(ctx, series); err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+
+	slog.Info("Series poll opened", "series_id", series.ID, "poll_id", poll.ID, "next_run_at", series.NextRunAt)
+
+	if err := s.NotifyChannel(series.ChannelID, formatOpening(poll)); err != nil {
+		slog.Error("Failed to announce series poll", "poll_id", poll.ID, "error", err)
+	}
+	return nil
+}
+
+// closeSeriesPoll closes the series' previous poll if it is still open and posts its results
+func (s *Service) closeSeriesPoll(ctx context.Context, pollID string) {
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		slog.Error("Failed to get previous series poll", "poll_id", pollID, "error", err)
+		return
+	}
+	if !poll.IsOpen() {
+		return
+	}
+
+	if err := s.closePoll(ctx, poll, ""); err != nil {
+		slog.Error("Failed to close previous series poll", "poll_id", pollID, "error", err)
+		return
+	}
+
+	results, err := s.FormatPollResults(ctx, pollID)
+	if err != nil {
+		slog.Error("Failed to format results of previous series poll", "poll_id", pollID, "error", err)
+		return
+	}
+
+	message := "Poll has been closed: the next poll of the series has opened.\n\n" + results
+	if err := s.NotifyChannel(poll.ChannelID, message); err != nil {
+		slog.Error("Failed to post results of previous series poll", "poll_id", pollID, "error", err)
+	}
+}
+
+// FormatSeriesHistory formats the results of the series' latest polls side by side,
+// with the shift of every option between the last two polls
+func (s *Service) FormatSeriesHistory(ctx context.Context, seriesID string) (string, error) {
+	series, err := s.getSeries(ctx, seriesID)
+	if err != nil {
+		return "", err
+	}
+
+	history := fmt.Sprintf("### History: %s\n\n**Schedule:** `%s` (UTC)", series.Title, series.Schedule)
+	if series.IsStopped() {
+		history += ", stopped\n\n"
+	} else {
+		history += fmt.Sprintf(", next poll at %s\n\n", model.FormatTime(series.NextRunAt))
+	}
+
+	pollIDs := series.PollIDs
+	if len(pollIDs) > historyLimit {
+		pollIDs = pollIDs[len(pollIDs)-historyLimit:]
+	}
+
+	polls := make([]*model.Poll, 0, len(pollIDs))
+	for _, pollID := range pollIDs {
+		poll, err := s.storage.GetPoll(ctx, pollID)
+		if err != nil {
+			if errors.Is(err, db.ErrNotFound) {
+				continue
+			}
+			return "", fmt.Errorf("failed to get poll: %w", err)
+		}
+		polls = append(polls, poll)
+	}
+
+	if len(polls) == 0 {
+		return history + "No polls have been opened yet.", nil
+	}
+
+	// single choice and approval polls are compared by the share of voters, others by their counts
+	shares := series.Settings.Type == model.PollTypeSingle || series.Settings.Type == model.PollTypeApproval
+
+	history += "| Opened | Voters | " + strings.Join(series.Options, " | ") + " | Winner |\n"
+	history += "|---|---|" + strings.Repeat("---|", len(series.Options)) + "---|\n"
+
+	values := make([]map[string]float64, len(polls))
+	for i, poll := range polls {
+		values[i] = seriesValues(poll, shares)
+
+		cells := make([]string, 0, len(series.Options))
+		for _, option := range series.Options {
+			cells = append(cells, formatSeriesValue(values[i][option], shares))
+		}
+
+		winner := "-"
+		if winners, err := winnersOf(poll); err == nil && len(winners) > 0 && !poll.IsSTV() {
+			winner = strings.Join(winners, ", ")
+		}
+		if poll.IsOpen() {
+			winner += " (open)"
+		}
+
+		history += fmt.Sprintf("| %s | %d | %s | %s |\n", model.FormatTime(poll.OpensAt), poll.VoterCount(),
+			strings.Join(cells, " | "), winner)
+	}
+
+	if len(polls) > 1 {
+		previous, latest := values[len(values)-2], values[len(values)-1]
+		history += "\n**Shift since the previous poll:**\n"
+		for _, option := range series.Options {
+			history += fmt.Sprintf("- %s: %s → %s (%s)\n", option,
+				formatSeriesValue(previous[option], shares), formatSeriesValue(latest[option], shares),
+				formatShift(latest[option]-previous[option], shares))
+		}
+	}
+
+	return history, nil
+}
+
+// seriesValues returns the counted votes of every option, or the percentage of voters
+// supporting it if shares is set
+func seriesValues(poll *model.Poll, shares bool) map[string]float64 {
+	values := countVotes(poll)
+	if !shares {
+		return values
+	}
+
+	total := poll.TotalWeight()
+	for option, value := range values {
+		if total > 0 {
+			values[option] = value / total * 100
+		}
+	}
+	return values
+}
+
+// formatSeriesValue formats a value of the history table
+func formatSeriesValue(value float64, shares bool) string {
+	if shares {
+		return fmt.Sprintf("%.0f%%", value)
+	}
+	return fmt.Sprintf("%g", value)
+}
+
+// formatShift formats the change of a value between two polls
+func formatShift(shift float64, shares bool) string {
+	text := fmt.Sprintf("%g", shift)
+	if shares {
+		text = fmt.Sprintf("%.0f pts", shift)
+	}
+
+	switch {
+	case shift > 0:
+		return "▲ +" + text
+	case shift < 0:
+		return "▼ " + text
+	default:
+		return "no change"
+	}
+}
@@ -5,20 +5,49 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"github.com/hard-gainer/voting-bot/internal/config"
 	"github.com/hard-gainer/voting-bot/internal/db"
 	"github.com/hard-gainer/voting-bot/internal/model"
 )
 
 // service errors
 var (
-	ErrPollNotFound  = errors.New("poll not found")
-	ErrPollInactive  = errors.New("poll is not active")
-	ErrInvalidOption = errors.New("invalid option")
-	ErrNotAuthorized = errors.New("not authorized to perform this action")
-	ErrAlreadyVoted  = errors.New("already voted in this poll")
+	ErrPollNotFound       = errors.New("poll not found")
+	ErrPollInactive       = errors.New("poll is not active")
+	ErrPollNotOpen        = errors.New("poll is not open yet")
+	ErrNotDraft           = errors.New("only drafts and scheduled polls can be published or scheduled")
+	ErrInvalidOption      = errors.New("invalid option")
+	ErrAmbiguousOption    = errors.New("ambiguous option")
+	ErrBudgetExceeded     = errors.New("voice credit budget exceeded")
+	ErrInvalidBallot      = errors.New("invalid ballot")
+	ErrInvalidScore       = errors.New("invalid score")
+	ErrInvalidType        = errors.New("unsupported poll type")
+	ErrInvalidMethod      = errors.New("unsupported tally method")
+	ErrNoVoteKey          = errors.New("anonymous polls are disabled: vote key secret is not configured")
+	ErrVotersHidden       = errors.New("voters of this poll are not public")
+	ErrSuggestionNotFound = errors.New("suggestion not found")
+	ErrNoTie              = errors.New("poll has no tie waiting for the owners' decision")
+	ErrNotAuthorized      = errors.New("not authorized to perform this action")
+	ErrAlreadyVoted       = errors.New("already voted in this poll, votes are final")
+	ErrVoteLocked         = errors.New("votes in this poll can no longer be changed")
+	ErrNotVoted           = errors.New("no vote in this poll")
+	ErrInvalidDeadline    = errors.New("closing time must be in the future")
+	ErrSeriesNotFound     = errors.New("recurring poll not found")
+	ErrTemplateNotFound   = errors.New("template not found")
+	ErrNotCloned          = errors.New("poll is not a clone of another poll")
+	ErrNotClosed          = errors.New("poll is not closed")
+	ErrNoDeadline         = errors.New("poll has no closing time to extend")
+	ErrTemplateExists     = errors.New("a shared template with this name belongs to another user")
+	ErrTrashed            = errors.New("poll is in the trash, restore it first")
+	ErrNotTrashed         = errors.New("poll is not in the trash")
+	ErrLastOwner          = errors.New("poll must keep at least one owner")
+	ErrReasonTooLong      = fmt.Errorf("reason is longer than %d characters", model.MaxReasonLength)
 )
 
 // MessageSender represents an interface for sending messages
@@ -26,17 +55,28 @@ type MessageSender interface {
 	PostMessage(channelID, message string) error
 }
 
+// Directory represents an interface for reaching channel members directly
+// and looking up their system roles
+type Directory interface {
+	ChannelMembers(channelID string) ([]string, error)
+	SendDirectMessage(userID, message string) error
+	IsSystemAdmin(userID string) (bool, error)
+}
+
 // Service represents service layer
 type Service struct {
-	storage  db.Storage
-	notifier MessageSender
+	storage   db.Storage
+	notifier  MessageSender
+	directory Directory
+	cfg       config.PollConfig
 }
 
 // NewService creates an instance of service
-func NewService(storage db.Storage, notifier MessageSender) *Service {
+func NewService(storage db.Storage, notifier MessageSender, cfg config.PollConfig) *Service {
 	return &Service{
 		storage:  storage,
 		notifier: notifier,
+		cfg:      cfg,
 	}
 }
 
@@ -55,10 +95,38 @@ func (s *Service) NotifyChannel(channelID, message string) error {
 	return s.notifier.PostMessage(channelID, message)
 }
 
-// CreatePoll creates a new poll
-func (s *Service) CreatePoll(ctx context.Context, title string, options []string, creatorID string) (*model.Poll, error) {
-	slog.Info("Creating poll", "title", title, "options_count", len(options), "creator", creatorID)
+// CreatePoll creates a new open poll in the channel. A non-zero closesAt is the unix time
+// the poll is closed automatically, alternatively settings.Duration sets how long it stays open
+func (s *Service) CreatePoll(ctx context.Context, title string, options []string, creatorID, channelID string, closesAt uint64, settings model.PollSettings) (*model.Poll, error) {
+	return s.createPoll(ctx, title, options, creatorID, channelID, closesAt, settings, model.StatusOpen)
+}
+
+// CreateDraft creates a poll draft that is hidden from the poll list until it is published or scheduled
+func (s *Service) CreateDraft(ctx context.Context, title string, options []string, creatorID, channelID string, closesAt uint64, settings model.PollSettings) (*model.Poll, error) {
+	return s.createPoll(ctx, title, options, creatorID, channelID, closesAt, settings, model.StatusDraft)
+}
+
+// createPoll validates and stores a new poll in the given lifecycle state
+func (s *Service) createPoll(ctx context.Context, title string, options []string, creatorID, channelID string, closesAt uint64, settings model.PollSettings, status string) (*model.Poll, error) {
+	slog.Info("Creating poll", "title", title, "options_count", len(options), "creator", creatorID,
+		"type", settings.Type, "method", settings.Method, "closes_at", closesAt, "status", status)
 
+	poll, err := s.newPoll(title, options, creatorID, channelID, closesAt, settings, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.CreatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to create poll", "error", err)
+		return nil, fmt.Errorf("failed to create poll: %w", err)
+	}
+
+	slog.Info("Poll created successfully", "poll_id", poll.ID)
+	return poll, nil
+}
+
+// newPoll validates the poll's title, options and settings and builds the poll without storing it
+func (s *Service) newPoll(title string, options []string, creatorID, channelID string, closesAt uint64, settings model.PollSettings, status string) (*model.Poll, error) {
 	if title == "" {
 		return nil, errors.New("empty poll title")
 	}
@@ -67,22 +135,49 @@ func (s *Service) CreatePoll(ctx context.Context, title string, options []string
 		return nil, errors.New("poll must have at least two options")
 	}
 
+	if err := validateOptions(options); err != nil {
+		return nil, err
+	}
+
+	settings, err := normalizeSettings(settings, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.Visibility == model.VisibilityAnonymous && s.cfg.VoteKeySecret == "" {
+		return nil, ErrNoVoteKey
+	}
+
+	if closesAt != 0 && settings.Duration != 0 {
+		return nil, errors.New("a poll has either a duration or a closing time")
+	}
+
+	if len(settings.Reminders) > 0 && closesAt == 0 && settings.Duration == 0 {
+		return nil, errors.New("reminders need a closing time or a duration")
+	}
+
+	now := uint64(time.Now().Unix())
+	if closesAt != 0 && closesAt <= now {
+		return nil, ErrInvalidDeadline
+	}
+
 	poll := &model.Poll{
 		ID:        uuid.New().String(),
 		Title:     title,
 		Options:   options,
 		CreatedBy: creatorID,
-		CreatedAt: uint64(time.Now().Unix()),
-		IsActive:  true,
-		Votes:     make(map[string]string),
+		CreatedAt: now,
+		Status:    status,
+		Votes:     make(map[string]model.Ballot),
+		Settings:  settings,
+		ChannelID: channelID,
+		ClosesAt:  closesAt,
 	}
 
-	if err := s.storage.CreatePoll(ctx, poll); err != nil {
-		slog.Error("Failed to create poll", "error", err)
-		return nil, fmt.Errorf("failed to create poll: %w", err)
+	if status == model.StatusOpen {
+		startPoll(poll, now)
 	}
 
-	slog.Info("Poll created successfully", "poll_id", poll.ID)
 	return poll, nil
 }
 
@@ -103,58 +198,156 @@ func (s *Service) GetPoll(ctx context.Context, pollID string) (*model.Poll, erro
 	return poll, nil
 }
 
-// HandleVote handles user's vote
-func (s *Service) HandleVote(ctx context.Context, pollID, option, userID string) error {
-	slog.Info("Handling vote", "poll_id", pollID, "option", option, "user_id", userID)
+// HandleVote handles user's vote. Ranked polls take the choices in order of preference,
+// approval polls take up to the poll's choice limit, score polls take "option=score"
+// assignments, quadratic polls take "option=votes" assignments within the credit budget,
+// other polls take exactly one choice. Choices of anonymous polls are never logged.
+// A repeated vote replaces the ballot if the poll's vote policy allows changes.
+// The optional reason is stored with the ballot. Options may be given by number,
+// case-insensitive text, leading emoji or unique prefix, the returned ballot holds the resolved options
+func (s *Service) HandleVote(ctx context.Context, pollID string, choices []string, reason, userID string) (*model.Poll, model.Ballot, error) {
+	slog.Info("Handling vote", "poll_id", pollID, "user_id", userID)
 
 	poll, err := s.storage.GetPoll(ctx, pollID)
 	if err != nil {
 		if errors.Is(err, db.ErrNotFound) {
-			return ErrPollNotFound
+			return nil, model.Ballot{}, ErrPollNotFound
 		}
-		return fmt.Errorf("failed to get poll: %w", err)
+		return nil, model.Ballot{}, fmt.Errorf("failed to get poll: %w", err)
 	}
 
-	if !poll.IsActive {
-		slog.Info("Attempted to vote in inactive poll", "poll_id", pollID, "user_id", userID)
-		return ErrPollInactive
+	if err := checkOpen(poll); err != nil {
+		slog.Info("Attempted to vote in poll that is not open", "poll_id", pollID, "user_id", userID, "status", poll.Status)
+		return nil, model.Ballot{}, err
+	}
+
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > model.MaxReasonLength {
+		return nil, model.Ballot{}, ErrReasonTooLong
 	}
 
-	optionValid := false
-	for _, opt := range poll.Options {
-		if opt == option {
-			optionValid = true
-			break
+	ballot, err := buildBallot(poll, choices)
+	if err != nil {
+		if poll.IsAnonymous() {
+			err = ballotError{err: err}
+			slog.Info("Invalid ballot", "poll_id", pollID, "error", err)
+			return nil, model.Ballot{}, err
 		}
+		slog.Info("Invalid ballot", "poll_id", pollID, "user_id", userID, "error", err)
+		return nil, model.Ballot{}, err
 	}
 
-	if !optionValid {
-		slog.Info("Invalid option selected", "poll_id", pollID, "option", option, "user_id", userID)
-		return ErrInvalidOption
+	voterKey, err := s.voterKey(poll, userID)
+	if err != nil {
+		return nil, model.Ballot{}, err
 	}
 
-	if existingOption, voted := poll.Votes[userID]; voted {
-		slog.Info("User updating vote", "poll_id", pollID, "user_id", userID,
-			"old_option", existingOption, "new_option", option)
+	if existingBallot, voted := poll.Votes[voterKey]; voted {
+		if err := checkVoteChange(poll); err != nil {
+			slog.Info("Vote change refused", "poll_id", pollID, "user_id", userID, "policy", poll.Settings.VotePolicy)
+			return nil, model.Ballot{}, err
+		}
+		slog.Info("User updating vote", ballotLogAttrs(poll, userID, "old", existingBallot)...)
 	}
 
 	if poll.Votes == nil {
-		poll.Votes = make(map[string]string)
+		poll.Votes = make(map[string]model.Ballot)
+	}
+
+	if poll.IsWeighted() {
+		ballot.Weight = poll.WeightOf(userID)
+	}
+	// a precise casting time next to the logged vote request would identify the voter,
+	// times stored for anonymous ballots by earlier versions are dropped as well
+	if poll.IsAnonymous() {
+		for key, existing := range poll.Votes {
+			existing.CastAt = 0
+			poll.Votes[key] = existing
+		}
+	} else {
+		ballot.CastAt = uint64(time.Now().UnixMilli())
 	}
+	ballot.Reason = reason
 
-	poll.Votes[userID] = option
+	poll.Votes[voterKey] = ballot
 
 	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
 		slog.Error("Failed to update poll with vote", "poll_id", pollID, "user_id", userID, "error", err)
-		return fmt.Errorf("failed to update poll: %w", err)
+		return nil, model.Ballot{}, fmt.Errorf("failed to update poll: %w", err)
 	}
 
-	slog.Info("Vote processed successfully", "poll_id", pollID, "user_id", userID, "option", option)
-	return nil
+	slog.Info("Vote processed successfully", ballotLogAttrs(poll, userID, "new", ballot)...)
+	return poll, ballot, nil
+}
+
+// RetractVote removes the user's ballot if the poll's vote policy allows changes
+func (s *Service) RetractVote(ctx context.Context, pollID, userID string) (*model.Poll, error) {
+	slog.Info("Retracting vote", "poll_id", pollID, "user_id", userID)
+
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrPollNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if err := checkOpen(poll); err != nil {
+		return nil, err
+	}
+
+	voterKey, err := s.voterKey(poll, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, voted := poll.Votes[voterKey]; !voted {
+		return nil, ErrNotVoted
+	}
+
+	if err := checkVoteChange(poll); err != nil {
+		return nil, err
+	}
+
+	delete(poll.Votes, voterKey)
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to retract vote", "poll_id", pollID, "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	slog.Info("Vote retracted", "poll_id", pollID, "user_id", userID)
+	return poll, nil
+}
+
+// checkOpen returns an error if the poll does not accept votes
+func checkOpen(poll *model.Poll) error {
+	switch poll.Status {
+	case model.StatusOpen:
+		return nil
+	case model.StatusDraft, model.StatusScheduled:
+		return ErrPollNotOpen
+	default:
+		return ErrPollInactive
+	}
 }
 
-// GetResults returns poll results
-func (s *Service) GetResults(ctx context.Context, pollID string) (map[string]int, error) {
+// checkVoteChange returns an error if the poll's vote policy forbids changing a cast ballot
+func checkVoteChange(poll *model.Poll) error {
+	if poll.CanChangeVote(uint64(time.Now().Unix())) {
+		return nil
+	}
+	if poll.Settings.VotePolicy == model.VotePolicyLock {
+		return ErrAlreadyVoted
+	}
+	return ErrVoteLocked
+}
+
+// GetResults returns poll results. For ranked polls the first preferences are counted,
+// for approval polls every selected option is counted, for score and quadratic polls
+// the scores and votes are summed.
+// Every ballot counts with the voter's weight
+func (s *Service) GetResults(ctx context.Context, pollID string) (map[string]float64, error) {
 	slog.Info("Getting results", "poll_id", pollID)
 
 	poll, err := s.storage.GetPoll(ctx, pollID)
@@ -165,21 +358,38 @@ func (s *Service) GetResults(ctx context.Context, pollID string) (map[string]int
 		return nil, fmt.Errorf("failed to get poll: %w", err)
 	}
 
-	results := make(map[string]int)
+	results := countVotes(poll)
+
+	slog.Info("Results calculated", "poll_id", pollID, "results", results)
+	return results, nil
+}
+
+// countVotes sums the weighted votes of every option, see GetResults
+func countVotes(poll *model.Poll) map[string]float64 {
+	results := make(map[string]float64)
 
 	for _, option := range poll.Options {
 		results[option] = 0
 	}
 
-	for _, vote := range poll.Votes {
-		results[vote]++
+	for _, ballot := range poll.Votes {
+		if poll.IsScored() || poll.IsQuadratic() {
+			for option, score := range ballot.Scores {
+				results[option] += float64(score) * ballot.Value()
+			}
+		} else if poll.IsApproval() {
+			for _, choice := range ballot.Choices {
+				results[choice] += ballot.Value()
+			}
+		} else if len(ballot.Choices) > 0 {
+			results[ballot.Choices[0]] += ballot.Value()
+		}
 	}
 
-	slog.Info("Results calculated", "poll_id", pollID, "results", results)
-	return results, nil
+	return results
 }
 
-// EndPoll ends a poll
+// EndPoll ends a poll and resolves a tie for the win with the poll's tie-break policy
 func (s *Service) EndPoll(ctx context.Context, pollID, userID string) error {
 	slog.Info("Ending poll", "poll_id", pollID, "user_id", userID)
 
@@ -191,70 +401,142 @@ func (s *Service) EndPoll(ctx context.Context, pollID, userID string) error {
 		return fmt.Errorf("failed to get poll: %w", err)
 	}
 
-	if poll.CreatedBy != userID {
-		slog.Info("Unauthorized attempt to end poll", "poll_id", pollID,
-			"creator", poll.CreatedBy, "requester", userID)
-		return ErrNotAuthorized
+	if err := authorize(poll, userID, "end poll"); err != nil {
+		return err
 	}
 
-	if !poll.IsActive {
+	switch poll.Status {
+	case model.StatusClosed:
 		slog.Info("Poll already inactive", "poll_id", pollID)
 		return nil
+	case model.StatusDraft, model.StatusScheduled:
+		return ErrPollNotOpen
+	case model.StatusTrashed:
+		return ErrTrashed
 	}
 
-	poll.IsActive = false
+	return s.closePoll(ctx, poll, userID)
+}
+
+// closePoll closes the poll and resolves a tie for the win. Polls ended by their owners
+// and polls closed by the scheduler both go through here, the closing is recorded in the
+// poll's history with the user who closed it, if any
+func (s *Service) closePoll(ctx context.Context, poll *model.Poll, userID string) error {
+	poll.Status = model.StatusClosed
+	poll.Events = append(poll.Events, model.PollEvent{
+		Type: model.EventClosed,
+		At:   uint64(time.Now().Unix()),
+		By:   userID,
+	})
+
+	if poll.Settings.TieBreak != "" {
+		if err := s.breakTie(ctx, poll); err != nil {
+			slog.Error("Failed to break tie", "poll_id", poll.ID, "error", err)
+			return err
+		}
+	}
 
 	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
-		slog.Error("Failed to update poll status", "poll_id", pollID, "error", err)
+		slog.Error("Failed to update poll status", "poll_id", poll.ID, "error", err)
 		return fmt.Errorf("failed to update poll: %w", err)
 	}
 
-	slog.Info("Poll ended successfully", "poll_id", pollID)
+	if poll.TieBreak != nil && poll.TieBreak.Policy == model.TieBreakCreator && poll.TieBreak.Winner == "" {
+		s.notifyOwners(poll, formatTieRequest(poll))
+	}
+
+	slog.Info("Poll ended successfully", "poll_id", poll.ID)
 	return nil
 }
 
-// DeletePoll deletes a poll
-func (s *Service) DeletePoll(ctx context.Context, pollID, userID string) error {
+// DeletePoll moves a poll to the trash, where it can be restored until it is purged.
+// It returns the unix time the poll will be purged at
+func (s *Service) DeletePoll(ctx context.Context, pollID, userID string) (uint64, error) {
 	slog.Info("Deleting poll", "poll_id", pollID, "user_id", userID)
 
 	poll, err := s.storage.GetPoll(ctx, pollID)
 	if err != nil {
 		if errors.Is(err, db.ErrNotFound) {
-			return ErrPollNotFound
+			return 0, ErrPollNotFound
 		}
-		return fmt.Errorf("failed to get poll: %w", err)
+		return 0, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if err := authorize(poll, userID, "delete poll"); err != nil {
+		return 0, err
 	}
 
-	if poll.CreatedBy != userID {
-		slog.Info("Unauthorized attempt to delete poll", "poll_id", pollID,
-			"creator", poll.CreatedBy, "requester", userID)
-		return ErrNotAuthorized
+	if poll.IsTrashed() {
+		return 0, fmt.Errorf("poll is already in the trash, it will be purged at %s", model.FormatTime(s.purgeAt(poll)))
 	}
 
-	if err := s.storage.DeletePoll(ctx, pollID); err != nil {
+	poll.DeletedFrom = poll.Status
+	poll.DeletedAt = uint64(time.Now().Unix())
+	poll.Status = model.StatusTrashed
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
 		slog.Error("Failed to delete poll", "poll_id", pollID, "error", err)
-		return fmt.Errorf("failed to delete poll: %w", err)
+		return 0, fmt.Errorf("failed to update poll: %w", err)
 	}
 
-	slog.Info("Poll deleted successfully", "poll_id", pollID)
-	return nil
+	slog.Info("Poll moved to the trash", "poll_id", pollID, "deleted_from", poll.DeletedFrom)
+	return s.purgeAt(poll), nil
 }
 
-// ListPolls returns a list of all polls
+// ListPolls returns a list of all polls except drafts and deleted polls
 func (s *Service) ListPolls(ctx context.Context) ([]*model.Poll, error) {
 	slog.Info("Listing all polls")
 
-	polls, err := s.storage.ListPolls(ctx)
+	allPolls, err := s.storage.ListPolls(ctx)
 	if err != nil {
 		slog.Error("Failed to list polls", "error", err)
 		return nil, fmt.Errorf("failed to list polls: %w", err)
 	}
 
+	polls := make([]*model.Poll, 0, len(allPolls))
+	for _, poll := range allPolls {
+		if !poll.IsDraft() && !poll.IsTrashed() {
+			polls = append(polls, poll)
+		}
+	}
+
 	slog.Info("Polls retrieved successfully", "count", len(polls))
 	return polls, nil
 }
 
-// GetActivePollsByUser returns active polls created by user
+// GetVoters returns the IDs of the users supporting every option of a public poll.
+// Ranked polls list first preferences, score polls list non-zero scores,
+// quadratic polls list non-zero votes
+func (s *Service) GetVoters(ctx context.Context, pollID string) (map[string][]string, error) {
+	slog.Info("Getting voters", "poll_id", pollID)
+
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrPollNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if !poll.IsPublic() {
+		return nil, ErrVotersHidden
+	}
+
+	voters := make(map[string][]string, len(poll.Options))
+	for userID, ballot := range poll.Votes {
+		for _, option := range supportedOptions(poll, ballot) {
+			voters[option] = append(voters[option], userID)
+		}
+	}
+
+	for option := range voters {
+		sort.Strings(voters[option])
+	}
+
+	return voters, nil
+}
+
+// GetActivePollsByUser returns active polls owned by user
 func (s *Service) GetActivePollsByUser(ctx context.Context, userID string) ([]*model.Poll, error) {
 	slog.Info("Getting active polls for user", "user_id", userID)
 
@@ -266,7 +548,7 @@ func (s *Service) GetActivePollsByUser(ctx context.Context, userID string) ([]*m
 
 	userPolls := make([]*model.Poll, 0)
 	for _, poll := range allPolls {
-		if poll.CreatedBy == userID && poll.IsActive {
+		if poll.IsOwner(userID) && poll.IsOpen() {
 			userPolls = append(userPolls, poll)
 		}
 	}
@@ -292,7 +574,8 @@ func (s *Service) GetPollVoteCounts(ctx context.Context, pollID string) (int, er
 	return voteCount, nil
 }
 
-// FormatPollResults formats the poll results
+// FormatPollResults formats the poll results. Closed decision polls state
+// their outcome before the counts
 func (s *Service) FormatPollResults(ctx context.Context, pollID string) (string, error) {
 	slog.Info("Formatting poll results", "poll_id", pollID)
 
@@ -304,6 +587,10 @@ func (s *Service) FormatPollResults(ctx context.Context, pollID string) (string,
 		return "", fmt.Errorf("failed to get poll: %w", err)
 	}
 
+	if poll.IsRanked() || poll.IsScored() || poll.IsQuadratic() {
+		return s.formatCountedResults(poll)
+	}
+
 	results, err := s.GetResults(ctx, pollID)
 	if err != nil {
 		return "", err
@@ -311,23 +598,64 @@ func (s *Service) FormatPollResults(ctx context.Context, pollID string) (string,
 
 	formattedResults := fmt.Sprintf("### Poll: %s\n\n", poll.Title)
 
-	if !poll.IsActive {
-		formattedResults += "**Status: Closed**\n\n"
+	formattedResults += formatStatus(poll)
+
+	if poll.IsDecision() {
+		if poll.IsClosed() {
+			formattedResults += formatDecision(poll, decide(poll, results))
+		} else {
+			formattedResults += formatRules(poll)
+		}
+	}
+
+	if poll.IsAnonymous() {
+		formattedResults += "_Anonymous poll: voters are not recorded._\n\n"
+	}
+
+	voters := poll.VoterCount()
+	unit := "votes"
+	if poll.IsApproval() {
+		unit = "approvals"
+		formattedResults += fmt.Sprintf("**Total voters: %d**\n\n", voters)
+	} else {
+		formattedResults += fmt.Sprintf("**Total votes: %d**\n\n", voters)
+	}
+
+	totalWeight := poll.TotalWeight()
+	if poll.IsWeighted() {
+		formattedResults += fmt.Sprintf("**Total weight: %.2f**\n\n", totalWeight)
 	}
 
-	totalVotes := len(poll.Votes)
-	formattedResults += fmt.Sprintf("**Total votes: %d**\n\n", totalVotes)
+	headcount := make(map[string]int, len(poll.Options))
+	for _, ballot := range poll.Votes {
+		for _, choice := range ballot.Choices {
+			headcount[choice]++
+			if !poll.IsApproval() {
+				break
+			}
+		}
+	}
 
 	formattedResults += "#### Results:\n"
 	for _, option := range poll.Options {
-		votes := results[option]
 		var percentage float64 = 0
-		if totalVotes > 0 {
-			percentage = float64(votes) / float64(totalVotes) * 100
+		if totalWeight > 0 {
+			percentage = results[option] / totalWeight * 100
 		}
-		formattedResults += fmt.Sprintf("- **%s**: %d votes (%.1f%%)\n", option, votes, percentage)
+		if poll.IsWeighted() {
+			formattedResults += fmt.Sprintf("- **%s**: %d %s, weighted %.2f (%.1f%%)\n",
+				option, headcount[option], unit, results[option], percentage)
+		} else {
+			formattedResults += fmt.Sprintf("- **%s**: %d %s (%.1f%%)\n", option, headcount[option], unit, percentage)
+		}
+	}
+
+	if poll.TieBreak != nil {
+		formattedResults += formatTieBreak(poll)
 	}
 
+	formattedResults += formatReasons(poll)
+
 	slog.Info("Results formatted successfully", "poll_id", pollID)
 	return formattedResults, nil
 }
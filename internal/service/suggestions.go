@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/hard-gainer/voting-bot/internal/db"
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// SuggestOption adds a write-in option to the poll's pending list.
+// The option joins the poll only after an owner approves it
+func (s *Service) SuggestOption(ctx context.Context, pollID, option, userID string) (*model.Poll, error) {
+	slog.Info("Suggesting option", "poll_id", pollID, "user_id", userID)
+
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrPollNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if err := checkOpen(poll); err != nil {
+		return nil, err
+	}
+
+	option = strings.TrimSpace(option)
+	if option == "" {
+		return nil, fmt.Errorf("%w: empty option", ErrInvalidOption)
+	}
+
+	if poll.HasOption(option) {
+		return nil, fmt.Errorf("%w: option %q is already offered or suggested", ErrInvalidOption, option)
+	}
+
+	poll.Suggestions = append(poll.Suggestions, model.Suggestion{
+		Option:      option,
+		SuggestedBy: userID,
+		SuggestedAt: uint64(time.Now().Unix()),
+	})
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to save suggestion", "poll_id", pollID, "error", err)
+		return nil, fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	slog.Info("Option suggested", "poll_id", pollID, "user_id", userID, "pending", len(poll.Suggestions))
+	return poll, nil
+}
+
+// ApproveSuggestion adds the pending suggestion with the given 1-based number to the poll's options
+func (s *Service) ApproveSuggestion(ctx context.Context, pollID string, number int, userID string) (model.Suggestion, error) {
+	return s.resolveSuggestion(ctx, pollID, number, userID, true)
+}
+
+// RejectSuggestion drops the pending suggestion with the given 1-based number
+func (s *Service) RejectSuggestion(ctx context.Context, pollID string, number int, userID string) (model.Suggestion, error) {
+	return s.resolveSuggestion(ctx, pollID, number, userID, false)
+}
+
+// resolveSuggestion removes the suggestion from the pending list and adds it to the options if approved.
+// Only the poll's owners can resolve suggestions
+func (s *Service) resolveSuggestion(ctx context.Context, pollID string, number int, userID string, approve bool) (model.Suggestion, error) {
+	slog.Info("Resolving suggestion", "poll_id", pollID, "number", number, "user_id", userID, "approve", approve)
+
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return model.Suggestion{}, ErrPollNotFound
+		}
+		return model.Suggestion{}, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if err := authorize(poll, userID, "resolve suggestion"); err != nil {
+		return model.Suggestion{}, err
+	}
+
+	if err := checkOpen(poll); err != nil {
+		return model.Suggestion{}, err
+	}
+
+	if number < 1 || number > len(poll.Suggestions) {
+		return model.Suggestion{}, ErrSuggestionNotFound
+	}
+
+	suggestion := poll.Suggestions[number-1]
+	if approve {
+		// the options may have changed since the suggestion was made
+		options := append(append([]string(nil), poll.Options...), suggestion.Option)
+		if err := validateOptions(options); err != nil {
+			return model.Suggestion{}, fmt.Errorf("%w, reject suggestion %d instead", err, number)
+		}
+		poll.Options = options
+	}
+	poll.Suggestions = append(poll.Suggestions[:number-1], poll.Suggestions[number:]...)
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to resolve suggestion", "poll_id", pollID, "error", err)
+		return model.Suggestion{}, fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	slog.Info("Suggestion resolved", "poll_id", pollID, "approve", approve, "options_count", len(poll.Options))
+	return suggestion, nil
+}
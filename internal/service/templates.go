@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hard-gainer/voting-bot/internal/db"
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// templateName matches valid template names
+var templateName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// SaveTemplate saves a poll definition under a name for the team. Shared templates can be used by
+// the whole team, private ones only by their author. Saving under an existing name replaces the template
+func (s *Service) SaveTemplate(ctx context.Context, teamID, name, title string, options []string, settings model.PollSettings, private bool, userID string) (*model.Template, error) {
+	name = strings.ToLower(name)
+	slog.Info("Saving template", "team_id", teamID, "name", name, "private", private, "user_id", userID)
+
+	if !templateName.MatchString(name) {
+		return nil, errors.New("template name must be up to 32 letters, digits, '-' or '_'")
+	}
+
+	if settings.VotePolicy == model.VotePolicyWindow {
+		return nil, errors.New("templates can't have a vote change cutoff, use --lock-votes or the default policy")
+	}
+
+	// the template is validated the same way as the polls created from it
+	poll, err := s.newPoll(title, options, userID, "", 0, settings, model.StatusDraft)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := ""
+	if private {
+		owner = userID
+	} else {
+		existing, err := s.storage.GetTemplate(ctx, teamID, "", name)
+		if err != nil && !errors.Is(err, db.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get template: %w", err)
+		}
+		if existing != nil && existing.CreatedBy != userID {
+			return nil, ErrTemplateExists
+		}
+	}
+
+	template := &model.Template{
+		TeamID:    teamID,
+		Name:      name,
+		Owner:     owner,
+		Title:     poll.Title,
+		Options:   poll.Options,
+		Settings:  poll.Settings,
+		CreatedBy: userID,
+		CreatedAt: uint64(time.Now().Unix()),
+	}
+
+	if err := s.storage.SaveTemplate(ctx, template); err != nil {
+		slog.Error("Failed to save template", "name", name, "error", err)
+		return nil, fmt.Errorf("failed to save template: %w", err)
+	}
+
+	return template, nil
+}
+
+// GetTemplate returns the template the user means by the name, the user's private
+// template takes precedence over a shared one
+func (s *Service) GetTemplate(ctx context.Context, teamID, name, userID string) (*model.Template, error) {
+	name = strings.ToLower(name)
+
+	for _, owner := range []string{userID, ""} {
+		template, err := s.storage.GetTemplate(ctx, teamID, owner, name)
+		if err == nil {
+			return template, nil
+		}
+		if !errors.Is(err, db.ErrNotFound) {
+			return nil, fmt.Errorf("failed to get template: %w", err)
+		}
+	}
+
+	return nil, ErrTemplateNotFound
+}
+
+// ListTemplates returns the team's shared templates and the user's private ones, sorted by name
+func (s *Service) ListTemplates(ctx context.Context, teamID, userID string) ([]*model.Template, error) {
+	all, err := s.storage.ListTemplates(ctx, teamID)
+	if err != nil {
+		slog.Error("Failed to list templates", "team_id", teamID, "error", err)
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	templates := make([]*model.Template, 0, len(all))
+	for _, template := range all {
+		if !template.IsPrivate() || template.Owner == userID {
+			templates = append(templates, template)
+		}
+	}
+
+	sort.SliceStable(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// DeleteTemplate deletes the template the user means by the name. Only its owners can delete it
+func (s *Service) DeleteTemplate(ctx context.Context, teamID, name, userID string) (*model.Template, error) {
+	slog.Info("Deleting template", "team_id", teamID, "name", name, "user_id", userID)
+
+	template, err := s.GetTemplate(ctx, teamID, name, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeOwners(template.OwnerIDs(), userID, "delete template", "name", template.Name); err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.DeleteTemplate(ctx, teamID, template.Owner, template.Name); err != nil {
+		slog.Error("Failed to delete template", "name", template.Name, "error", err)
+		return nil, fmt.Errorf("failed to delete template: %w", err)
+	}
+
+	return template, nil
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"strings"
+
+	"github.com/hard-gainer/voting-bot/internal/db"
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// validateTieBreak checks that the tie-break policy is known and applies to the poll type
+func validateTieBreak(settings model.PollSettings) error {
+	switch settings.TieBreak {
+	case "":
+		return nil
+	case model.TieBreakCreator, model.TieBreakRandom, model.TieBreakEarliest, model.TieBreakRunoff:
+	default:
+		return fmt.Errorf("unsupported tie-break policy %q: use creator, random, earliest or runoff", settings.TieBreak)
+	}
+
+	if settings.Type == model.PollTypeSTV {
+		return fmt.Errorf("%w: tie-break policies do not apply to stv polls", ErrInvalidType)
+	}
+	if settings.TieBreak == model.TieBreakEarliest && settings.Visibility == model.VisibilityAnonymous {
+		return errors.New("anonymous polls can't use the earliest tie-break: ballots don't record when they were cast")
+	}
+	if settings.Quorum != 0 || settings.Threshold != "" {
+		return errors.New("tie-break policies do not apply to decision polls: a tie fails them")
+	}
+	return nil
+}
+
+// winnersOf returns the options winning the poll, several winners mean a tie
+func winnersOf(poll *model.Poll) ([]string, error) {
+	if len(poll.Votes) == 0 {
+		return nil, nil
+	}
+
+	if poll.IsRanked() || poll.IsScored() || poll.IsQuadratic() {
+		counter, err := counterFor(poll)
+		if err != nil {
+			return nil, err
+		}
+
+		ballots := make([]model.Ballot, 0, len(poll.Votes))
+		for _, ballot := range poll.Votes {
+			ballots = append(ballots, ballot)
+		}
+		return counter.Count(poll.Options, ballots).Winners, nil
+	}
+
+	results := countVotes(poll)
+
+	var winners []string
+	best := 0.0
+	for _, option := range poll.Options {
+		switch {
+		case len(winners) == 0 || results[option] > best:
+			winners = []string{option}
+			best = results[option]
+		case results[option] == best:
+			winners = append(winners, option)
+		}
+	}
+	return winners, nil
+}
+
+// breakTie applies the poll's tie-break policy if the poll ended in a tie.
+// The creator policy leaves the winner empty until an owner picks one,
+// the runoff policy creates a single choice poll between the tied options
+func (s *Service) breakTie(ctx context.Context, poll *model.Poll) error {
+	winners, err := winnersOf(poll)
+	if err != nil {
+		return err
+	}
+	if len(winners) < 2 {
+		return nil
+	}
+
+	tieBreak := &model.TieBreak{
+		Policy: poll.Settings.TieBreak,
+		Tied:   winners,
+	}
+
+	switch tieBreak.Policy {
+	case model.TieBreakRandom:
+		tieBreak.Seed = rand.Int63()
+		tieBreak.Winner = drawWinner(winners, tieBreak.Seed)
+	case model.TieBreakEarliest:
+		// anonymous ballots cast before casting times were dropped for them are not compared either
+		if !poll.IsAnonymous() {
+			tieBreak.Winner = earliestToCount(poll, winners)
+		}
+		if tieBreak.Winner == "" {
+			// the casting times don't separate the options, fall back to a recorded draw
+			tieBreak.Seed = rand.Int63()
+			tieBreak.Winner = drawWinner(winners, tieBreak.Seed)
+		}
+	case model.TieBreakRunoff:
+		runoff, err := s.newPoll("Runoff: "+poll.Title, winners, poll.CreatedBy, poll.ChannelID, 0, model.PollSettings{
+			Weights:    poll.Settings.Weights,
+			Visibility: poll.Settings.Visibility,
+			TieBreak:   model.TieBreakRandom,
+		}, model.StatusOpen)
+		if err != nil {
+			return fmt.Errorf("failed to create runoff poll: %w", err)
+		}
+		// the runoff is managed by the same owners as the tied poll
+		runoff.Owners = poll.Owners
+		if err := s.storage.CreatePoll(ctx, runoff); err != nil {
+			return fmt.Errorf("failed to create runoff poll: %w", err)
+		}
+		tieBreak.RunoffID = runoff.ID
+	}
+
+	poll.TieBreak = tieBreak
+
+	slog.Info("Tie resolved", "poll_id", poll.ID, "policy", tieBreak.Policy, "tied", tieBreak.Tied,
+		"winner", tieBreak.Winner, "seed", tieBreak.Seed, "runoff_id", tieBreak.RunoffID)
+	return nil
+}
+
+// drawWinner draws one of the tied options. The same seed and options always draw the same winner,
+// so the draw can be reproduced from the recorded seed
+func drawWinner(tied []string, seed int64) string {
+	return tied[rand.New(rand.NewSource(seed)).Intn(len(tied))]
+}
+
+// earliestToCount returns the tied option whose last supporting ballot was cast first,
+// that is the option that reached its final count earliest. Ranked polls use first preferences.
+// An empty string is returned if the timestamps do not separate the options
+func earliestToCount(poll *model.Poll, tied []string) string {
+	completed := make(map[string]uint64, len(tied))
+	for _, ballot := range poll.Votes {
+		for _, option := range supportedOptions(poll, ballot) {
+			if ballot.CastAt > completed[option] {
+				completed[option] = ballot.CastAt
+			}
+		}
+	}
+
+	winner := ""
+	var earliest uint64
+	for i, option := range tied {
+		switch {
+		case i == 0 || completed[option] < earliest:
+			winner = option
+			earliest = completed[option]
+		case completed[option] == earliest:
+			winner = ""
+		}
+	}
+	return winner
+}
+
+// PickWinner resolves a tie of a closed poll with the creator tie-break policy
+func (s *Service) PickWinner(ctx context.Context, pollID, option, userID string) (*model.Poll, error) {
+	slog.Info("Picking tie winner", "poll_id", pollID, "user_id", userID)
+
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrPollNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if err := authorize(poll, userID, "break tie"); err != nil {
+		return nil, err
+	}
+
+	tieBreak := poll.TieBreak
+	if tieBreak == nil || tieBreak.Policy != model.TieBreakCreator || tieBreak.Winner != "" {
+		return nil, ErrNoTie
+	}
+
+	option, err = resolveOption(poll, option)
+	if err != nil {
+		return nil, err
+	}
+
+	tied := false
+	for _, candidate := range tieBreak.Tied {
+		if candidate == option {
+			tied = true
+			break
+		}
+	}
+	if !tied {
+		return nil, fmt.Errorf("%w: pick one of %s", ErrInvalidOption, strings.Join(tieBreak.Tied, ", "))
+	}
+
+	tieBreak.Winner = option
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to save tie winner", "poll_id", pollID, "error", err)
+		return nil, fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	slog.Info("Tie winner picked", "poll_id", pollID, "winner", option)
+	return poll, nil
+}
+
+// formatTieRequest asks the owners of a tied poll to pick the winner,
+// with the command for each of the tied options
+func formatTieRequest(poll *model.Poll) string {
+	message := fmt.Sprintf("Poll **%s** (ID: `%s`) ended in a tie between %s. As its owner, pick the winner:\n",
+		poll.Title, poll.ID, quoteOptions(poll.TieBreak.Tied))
+	for _, option := range poll.TieBreak.Tied {
+		message += fmt.Sprintf("- `/poll-tiebreak %s %q`\n", poll.ID, option)
+	}
+	return message
+}
+
+// formatTieBreak states the winner of a tied poll and how the tie was resolved
+func formatTieBreak(poll *model.Poll) string {
+	tieBreak := poll.TieBreak
+	tied := strings.Join(tieBreak.Tied, ", ")
+
+	switch {
+	case tieBreak.Policy == model.TieBreakRunoff:
+		return fmt.Sprintf("\nTie between %s: a runoff poll has been created, vote with `/poll-vote %s \"Option\"`\n",
+			tied, tieBreak.RunoffID)
+	case tieBreak.Winner == "":
+		return fmt.Sprintf("\nTie between %s: a poll owner picks the winner with `/poll-tiebreak %s \"Option\"`\n",
+			tied, poll.ID)
+	}
+
+	var how string
+	switch {
+	case tieBreak.Policy == model.TieBreakCreator:
+		how = "by a poll owner"
+	case tieBreak.Policy == model.TieBreakEarliest && tieBreak.Seed == 0:
+		how = "in favour of the option that reached its count first"
+	case tieBreak.Policy == model.TieBreakEarliest:
+		how = fmt.Sprintf("by a random draw with seed %d, as the votes were cast at the same time", tieBreak.Seed)
+	default:
+		how = fmt.Sprintf("by a random draw with seed %d", tieBreak.Seed)
+	}
+
+	return fmt.Sprintf("\n**Winner: %s** (tie between %s broken %s)\n", tieBreak.Winner, tied, how)
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/hard-gainer/voting-bot/internal/db"
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// purgeAt returns the unix time the trashed poll is purged at
+func (s *Service) purgeAt(poll *model.Poll) uint64 {
+	return poll.DeletedAt + uint64(s.cfg.TrashRetentionDays)*uint64(24*time.Hour/time.Second)
+}
+
+// RestorePoll brings a poll back from the trash in the state it was deleted in
+func (s *Service) RestorePoll(ctx context.Context, pollID, userID string) (*model.Poll, error) {
+	slog.Info("Restoring poll", "poll_id", pollID, "user_id", userID)
+
+	poll, err := s.storage.GetPoll(ctx, pollID)
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, ErrPollNotFound
+		}
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+
+	if err := authorize(poll, userID, "restore poll"); err != nil {
+		return nil, err
+	}
+
+	if !poll.IsTrashed() {
+		return nil, ErrNotTrashed
+	}
+
+	poll.Status = poll.DeletedFrom
+	if poll.Status == "" {
+		poll.Status = model.StatusClosed
+	}
+	poll.DeletedAt = 0
+	poll.DeletedFrom = ""
+
+	if err := s.storage.UpdatePoll(ctx, poll); err != nil {
+		slog.Error("Failed to restore poll", "poll_id", pollID, "error", err)
+		return nil, fmt.Errorf("failed to update poll: %w", err)
+	}
+
+	// an open poll whose closing time passed in the trash is closed by the next scheduler run
+	slog.Info("Poll restored", "poll_id", pollID, "status", poll.Status)
+	return poll, nil
+}
+
+// FormatTrash formats the deleted polls the user owns with the time each of them is purged at
+func (s *Service) FormatTrash(ctx context.Context, userID string) (string, error) {
+	slog.Info("Listing trash", "user_id", userID)
+
+	trashed, err := s.storage.ListTrashedPolls(ctx)
+	if err != nil {
+		slog.Error("Failed to list trashed polls", "error", err)
+		return "", fmt.Errorf("failed to list trashed polls: %w", err)
+	}
+
+	trash := "### Trash\n\n"
+	count := 0
+	for _, poll := range trashed {
+		if !poll.IsOwner(userID) {
+			continue
+		}
+		trash += fmt.Sprintf("- **%s** (ID: `%s`), deleted at %s, purged at %s\n", poll.Title, poll.ID,
+			model.FormatTime(poll.DeletedAt), model.FormatTime(s.purgeAt(poll)))
+		count++
+	}
+
+	if count == 0 {
+		return trash + "You have no deleted polls.", nil
+	}
+
+	trash += fmt.Sprintf("\nDeleted polls are kept for %d days. To restore one: `/poll-restore [poll-id]`", s.cfg.TrashRetentionDays)
+	return trash, nil
+}
+
+// purgeTrash permanently removes the polls that have been in the trash longer than the retention period
+func (s *Service) purgeTrash(ctx context.Context) {
+	trashed, err := s.storage.ListTrashedPolls(ctx)
+	if err != nil {
+		slog.Error("Failed to list trashed polls", "error", err)
+		return
+	}
+
+	now := uint64(time.Now().Unix())
+	for _, poll := range trashed {
+		if s.purgeAt(poll) > now {
+			continue
+		}
+
+		if err := s.storage.DeletePoll(ctx, poll.ID); err != nil {
+			slog.Error("Failed to purge poll", "poll_id", poll.ID, "error", err)
+			continue
+		}
+		slog.Info("Poll purged from the trash", "poll_id", poll.ID, "deleted_at", poll.DeletedAt)
+	}
+}
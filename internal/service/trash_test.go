@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/config"
+	"github.com/hard-gainer/voting-bot/internal/db"
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// memStorage keeps polls in memory, storage methods the tests don't need are left unimplemented
+type memStorage struct {
+	db.Storage
+	polls map[string]*model.Poll
+}
+
+func (m *memStorage) GetPoll(_ context.Context, id string) (*model.Poll, error) {
+	poll, ok := m.polls[id]
+	if !ok {
+		return nil, db.ErrNotFound
+	}
+	copied := *poll
+	return &copied, nil
+}
+
+func (m *memStorage) UpdatePoll(_ context.Context, poll *model.Poll) error {
+	m.polls[poll.ID] = poll
+	return nil
+}
+
+// trashedPoll returns an open poll deleted by its owner
+func trashedPoll() *model.Poll {
+	return &model.Poll{
+		ID:          "poll1",
+		Options:     []string{"A", "B"},
+		CreatedBy:   "owner",
+		Status:      model.StatusTrashed,
+		Votes:       map[string]model.Ballot{"u1": {Choices: []string{"A"}}, "u2": {Choices: []string{"B"}}},
+		Settings:    model.PollSettings{TieBreak: model.TieBreakRunoff},
+		DeletedAt:   1700000000,
+		DeletedFrom: model.StatusOpen,
+	}
+}
+
+func TestRestorePoll(t *testing.T) {
+	tests := []struct {
+		name   string
+		poll   func() *model.Poll
+		userID string
+		status string
+		err    error
+	}{
+		{name: "restored in the state it was deleted in", poll: trashedPoll, userID: "owner", status: model.StatusOpen},
+		{
+			name: "restored closed without a recorded state",
+			poll: func() *model.Poll {
+				poll := trashedPoll()
+				poll.DeletedFrom = ""
+				return poll
+			},
+			userID: "owner",
+			status: model.StatusClosed,
+		},
+		{name: "only owners restore", poll: trashedPoll, userID: "someone", err: ErrNotAuthorized},
+		{
+			name: "poll not in the trash",
+			poll: func() *model.Poll {
+				poll := trashedPoll()
+				poll.Status, poll.DeletedAt, poll.DeletedFrom = model.StatusOpen, 0, ""
+				return poll
+			},
+			userID: "owner",
+			err:    ErrNotTrashed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &memStorage{polls: map[string]*model.Poll{"poll1": tt.poll()}}
+			s := NewService(storage, nil, config.PollConfig{})
+
+			poll, err := s.RestorePoll(context.Background(), "poll1", tt.userID)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("err = %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			stored := storage.polls["poll1"]
+			if poll.Status != tt.status || stored.Status != tt.status {
+				t.Errorf("status = %q, stored %q, want %q", poll.Status, stored.Status, tt.status)
+			}
+			if stored.DeletedAt != 0 || stored.DeletedFrom != "" {
+				t.Errorf("deletion not cleared: deleted at %d from %q", stored.DeletedAt, stored.DeletedFrom)
+			}
+		})
+	}
+}
+
+func TestEndPollTrashed(t *testing.T) {
+	storage := &memStorage{polls: map[string]*model.Poll{"poll1": trashedPoll()}}
+	s := NewService(storage, nil, config.PollConfig{})
+
+	if err := s.EndPoll(context.Background(), "poll1", "owner"); !errors.Is(err, ErrTrashed) {
+		t.Fatalf("err = %v, want %v", err, ErrTrashed)
+	}
+
+	stored := storage.polls["poll1"]
+	if stored.Status != model.StatusTrashed || stored.DeletedAt == 0 || stored.TieBreak != nil {
+		t.Errorf("trashed poll changed: status %q, deleted at %d, tie-break %+v", stored.Status, stored.DeletedAt, stored.TieBreak)
+	}
+}
@@ -0,0 +1,37 @@
+package tally
+
+import (
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// Borda implements the Borda count
+type Borda struct{}
+
+// Name returns the name of the method
+func (Borda) Name() string {
+	return "Borda count"
+}
+
+// Count awards n-1 points for a first preference, n-2 for a second
+// and so on, where n is the number of options. Unranked options get no points.
+// Points are multiplied by the ballot weight
+func (Borda) Count(options []string, ballots []model.Ballot) *Result {
+	points := make(map[string]float64, len(options))
+	for _, ballot := range ballots {
+		for option, position := range rank(ballot) {
+			if position < len(options) {
+				points[option] += float64(len(options)-1-position) * ballot.Value()
+			}
+		}
+	}
+
+	rows := make([][]string, 0, len(options))
+	for _, option := range options {
+		rows = append(rows, []string{option, num(points[option])})
+	}
+
+	return &Result{
+		Winners: topScored(options, points),
+		Report:  formatTable([]string{"Option", "Points"}, rows),
+	}
+}
@@ -0,0 +1,30 @@
+package tally
+
+import (
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+func TestBorda(t *testing.T) {
+	run(t, Borda{}, []countTest{
+		{
+			name:    "most points wins",
+			options: []string{"A", "B", "C"},
+			ballots: []model.Ballot{ranked("A", "B", "C"), ranked("A", "C", "B"), ranked("B", "A", "C")},
+			winners: []string{"A"},
+		},
+		{
+			name:    "unranked options get no points",
+			options: []string{"A", "B", "C"},
+			ballots: []model.Ballot{ranked("C"), ranked("A", "B")},
+			winners: []string{"A", "C"},
+		},
+		{
+			name:    "points are multiplied by the weight",
+			options: []string{"A", "B"},
+			ballots: []model.Ballot{weighted(2, ranked("B", "A")), ranked("A", "B")},
+			winners: []string{"B"},
+		},
+	})
+}
@@ -0,0 +1,131 @@
+package tally
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// IRV implements instant-runoff voting
+type IRV struct{}
+
+// Name returns the name of the method
+func (IRV) Name() string {
+	return "Instant-runoff"
+}
+
+// Count runs elimination rounds until an option holds a majority
+// of the ballots that still rank a continuing option.
+// All options tied for the lowest count are eliminated together.
+// Every ballot counts with its weight.
+func (IRV) Count(options []string, ballots []model.Ballot) *Result {
+	continuing := make(map[string]bool, len(options))
+	for _, option := range options {
+		continuing[option] = true
+	}
+
+	var rounds []map[string]float64
+	var notes []string
+	var winners []string
+
+	for len(winners) == 0 {
+		remaining := remainingOptions(options, continuing)
+		counts := make(map[string]float64, len(remaining))
+		for _, option := range remaining {
+			counts[option] = 0
+		}
+
+		active := 0.0
+		for _, ballot := range ballots {
+			for _, choice := range ballot.Choices {
+				if continuing[choice] {
+					counts[choice] += ballot.Value()
+					active += ballot.Value()
+					break
+				}
+			}
+		}
+		rounds = append(rounds, counts)
+
+		for _, option := range remaining {
+			if counts[option]*2 > active {
+				winners = []string{option}
+				notes = append(notes, fmt.Sprintf("%s wins with a majority", option))
+			}
+		}
+		if len(winners) > 0 {
+			break
+		}
+
+		lowest := lowestCounted(remaining, counts)
+		if len(lowest) == len(remaining) {
+			winners = remaining
+			notes = append(notes, "remaining options are tied")
+			break
+		}
+
+		for _, option := range lowest {
+			continuing[option] = false
+		}
+		notes = append(notes, strings.Join(lowest, ", ")+" eliminated")
+	}
+
+	header := []string{"Option"}
+	for i := range rounds {
+		header = append(header, fmt.Sprintf("Round %d", i+1))
+	}
+
+	rows := make([][]string, 0, len(options))
+	for _, option := range options {
+		row := []string{option}
+		for _, counts := range rounds {
+			if count, ok := counts[option]; ok {
+				row = append(row, num(count))
+			} else {
+				row = append(row, "–")
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	var report strings.Builder
+	report.WriteString(formatTable(header, rows))
+	report.WriteString("\n")
+	for i, note := range notes {
+		report.WriteString(fmt.Sprintf("- Round %d: %s\n", i+1, note))
+	}
+
+	return &Result{
+		Winners: winners,
+		Report:  report.String(),
+	}
+}
+
+// remainingOptions returns continuing options in their original order
+func remainingOptions(options []string, continuing map[string]bool) []string {
+	remaining := make([]string, 0, len(options))
+	for _, option := range options {
+		if continuing[option] {
+			remaining = append(remaining, option)
+		}
+	}
+	return remaining
+}
+
+// lowestCounted returns the options with the lowest count
+func lowestCounted(options []string, counts map[string]float64) []string {
+	var lowest []string
+	fewest := 0.0
+	for _, option := range options {
+		count := counts[option]
+		switch {
+		case len(lowest) == 0 || count < fewest:
+			lowest = []string{option}
+			fewest = count
+		case count == fewest:
+			lowest = append(lowest, option)
+		}
+	}
+	return lowest
+}
@@ -0,0 +1,53 @@
+package tally
+
+import (
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+func TestIRV(t *testing.T) {
+	run(t, IRV{}, []countTest{
+		{
+			name:    "majority in the first round",
+			options: []string{"A", "B", "C"},
+			ballots: []model.Ballot{ranked("A"), ranked("A", "B"), ranked("B")},
+			winners: []string{"A"},
+		},
+		{
+			name:    "eliminated option transfers to the next preference",
+			options: []string{"A", "B", "C"},
+			ballots: join(repeat(2, ranked("A")), repeat(2, ranked("B")), repeat(1, ranked("C", "B"))),
+			winners: []string{"B"},
+		},
+		{
+			name:    "options tied for the lowest count are eliminated together",
+			options: []string{"A", "B", "C"},
+			ballots: join(repeat(2, ranked("A")), repeat(1, ranked("B")), repeat(1, ranked("C"))),
+			winners: []string{"A"},
+		},
+		{
+			name:    "exhausted ballots don't count towards the majority",
+			options: []string{"A", "B", "C"},
+			ballots: join(repeat(2, ranked("A")), repeat(2, ranked("B", "A")), repeat(3, ranked("C"))),
+			winners: []string{"C"},
+		},
+		{
+			name:    "weights count",
+			options: []string{"A", "B"},
+			ballots: []model.Ballot{weighted(3, ranked("A")), ranked("B"), ranked("B")},
+			winners: []string{"A"},
+		},
+		{
+			name:    "remaining options tied",
+			options: []string{"A", "B"},
+			ballots: []model.Ballot{ranked("A"), ranked("B")},
+			winners: []string{"A", "B"},
+		},
+		{
+			name:    "no ballots",
+			options: []string{"A", "B"},
+			winners: []string{"A", "B"},
+		},
+	})
+}
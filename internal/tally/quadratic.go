@@ -0,0 +1,43 @@
+package tally
+
+import (
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// Quadratic counts the net votes of quadratic voting
+type Quadratic struct{}
+
+// Name returns the name of the method
+func (Quadratic) Name() string {
+	return "Quadratic voting"
+}
+
+// Count sums the votes cast for and against every option.
+// Votes are multiplied by the ballot weight
+func (Quadratic) Count(options []string, ballots []model.Ballot) *Result {
+	net := make(map[string]float64, len(options))
+	votesFor := make(map[string]float64, len(options))
+	votesAgainst := make(map[string]float64, len(options))
+
+	for _, ballot := range ballots {
+		for option, votes := range ballot.Scores {
+			value := float64(votes) * ballot.Value()
+			net[option] += value
+			if value > 0 {
+				votesFor[option] += value
+			} else {
+				votesAgainst[option] -= value
+			}
+		}
+	}
+
+	rows := make([][]string, 0, len(options))
+	for _, option := range options {
+		rows = append(rows, []string{option, num(net[option]), num(votesFor[option]), num(votesAgainst[option])})
+	}
+
+	return &Result{
+		Winners: topScored(options, net),
+		Report:  formatTable([]string{"Option", "Net votes", "For", "Against"}, rows),
+	}
+}
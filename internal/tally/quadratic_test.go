@@ -0,0 +1,48 @@
+package tally
+
+import (
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+func TestQuadratic(t *testing.T) {
+	run(t, Quadratic{}, []countTest{
+		{
+			name:    "most net votes wins",
+			options: []string{"A", "B"},
+			ballots: []model.Ballot{
+				scored(map[string]int{"A": 3, "B": -1}),
+				scored(map[string]int{"B": 2}),
+			},
+			winners: []string{"A"},
+		},
+		{
+			name:    "votes against lower the net count",
+			options: []string{"A", "B", "C"},
+			ballots: []model.Ballot{
+				scored(map[string]int{"A": -3}),
+				scored(map[string]int{"B": -1}),
+			},
+			winners: []string{"C"},
+		},
+		{
+			name:    "votes are multiplied by the weight",
+			options: []string{"A", "B"},
+			ballots: []model.Ballot{
+				scored(map[string]int{"A": 2}),
+				weighted(3, scored(map[string]int{"B": 1})),
+			},
+			winners: []string{"B"},
+		},
+		{
+			name:    "tie",
+			options: []string{"A", "B"},
+			ballots: []model.Ballot{
+				scored(map[string]int{"A": 1}),
+				scored(map[string]int{"B": 1}),
+			},
+			winners: []string{"A", "B"},
+		},
+	})
+}
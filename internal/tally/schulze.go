@@ -0,0 +1,99 @@
+package tally
+
+import (
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// Schulze implements the Schulze method
+type Schulze struct{}
+
+// Name returns the name of the method
+func (Schulze) Name() string {
+	return "Schulze method"
+}
+
+// Count builds the pairwise preference matrix and picks the options
+// whose strongest paths beat or equal those of every other option.
+// A ranked option is preferred over any unranked one.
+// Every ballot counts with its weight.
+func (Schulze) Count(options []string, ballots []model.Ballot) *Result {
+	n := len(options)
+
+	pairwise := make([][]float64, n)
+	for i := range pairwise {
+		pairwise[i] = make([]float64, n)
+	}
+
+	for _, ballot := range ballots {
+		positions := rank(ballot)
+		for i, a := range options {
+			for j, b := range options {
+				if i == j {
+					continue
+				}
+				posA, rankedA := positions[a]
+				posB, rankedB := positions[b]
+				if rankedA && (!rankedB || posA < posB) {
+					pairwise[i][j] += ballot.Value()
+				}
+			}
+		}
+	}
+
+	strength := make([][]float64, n)
+	for i := range strength {
+		strength[i] = make([]float64, n)
+		for j := range strength[i] {
+			if i != j && pairwise[i][j] > pairwise[j][i] {
+				strength[i][j] = pairwise[i][j]
+			}
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if i == k {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if j == i || j == k {
+					continue
+				}
+				strength[i][j] = max(strength[i][j], min(strength[i][k], strength[k][j]))
+			}
+		}
+	}
+
+	var winners []string
+	for i, option := range options {
+		wins := true
+		for j := range options {
+			if i != j && strength[j][i] > strength[i][j] {
+				wins = false
+				break
+			}
+		}
+		if wins {
+			winners = append(winners, option)
+		}
+	}
+
+	header := append([]string{"Preferred over →"}, options...)
+	rows := make([][]string, 0, n)
+	for i, option := range options {
+		row := []string{option}
+		for j := range options {
+			if i == j {
+				row = append(row, "–")
+			} else {
+				row = append(row, num(pairwise[i][j]))
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return &Result{
+		Winners: winners,
+		Report:  formatTable(header, rows),
+	}
+}
@@ -0,0 +1,46 @@
+package tally
+
+import (
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+func TestSchulze(t *testing.T) {
+	run(t, Schulze{}, []countTest{
+		{
+			name:    "condorcet winner",
+			options: []string{"A", "B", "C"},
+			ballots: join(repeat(2, ranked("A", "B", "C")), repeat(1, ranked("B", "C", "A"))),
+			winners: []string{"A"},
+		},
+		{
+			// the example from Schulze's paper, 45 voters
+			name:    "strongest paths break a cycle",
+			options: []string{"A", "B", "C", "D", "E"},
+			ballots: []model.Ballot{
+				weighted(5, ranked("A", "C", "B", "E", "D")),
+				weighted(5, ranked("A", "D", "E", "C", "B")),
+				weighted(8, ranked("B", "E", "D", "A", "C")),
+				weighted(3, ranked("C", "A", "B", "E", "D")),
+				weighted(7, ranked("C", "A", "E", "B", "D")),
+				weighted(2, ranked("C", "B", "A", "D", "E")),
+				weighted(7, ranked("D", "C", "E", "B", "A")),
+				weighted(8, ranked("E", "B", "A", "D", "C")),
+			},
+			winners: []string{"E"},
+		},
+		{
+			name:    "symmetric cycle ties every option",
+			options: []string{"A", "B", "C"},
+			ballots: []model.Ballot{ranked("A", "B", "C"), ranked("B", "C", "A"), ranked("C", "A", "B")},
+			winners: []string{"A", "B", "C"},
+		},
+		{
+			name:    "ranked options beat unranked ones",
+			options: []string{"A", "B", "C"},
+			ballots: []model.Ballot{ranked("A"), ranked("B", "C")},
+			winners: []string{"A", "B"},
+		},
+	})
+}
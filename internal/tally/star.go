@@ -0,0 +1,97 @@
+package tally
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// STAR implements score-then-automatic-runoff voting
+type STAR struct{}
+
+// Name returns the name of the method
+func (STAR) Name() string {
+	return "STAR voting"
+}
+
+// Count sums the scores of every option and runs an automatic runoff between
+// the two highest-scored options. Each ballot supports the finalist it scored higher.
+// A runoff tie is broken by the total score. Scores and runoff
+// preferences are multiplied by the ballot weight.
+func (STAR) Count(options []string, ballots []model.Ballot) *Result {
+	totals := make(map[string]float64, len(options))
+	weight := 0.0
+	for _, ballot := range ballots {
+		for option, score := range ballot.Scores {
+			totals[option] += float64(score) * ballot.Value()
+		}
+		weight += ballot.Value()
+	}
+
+	rows := make([][]string, 0, len(options))
+	for _, option := range options {
+		average := 0.0
+		if weight > 0 {
+			average = totals[option] / weight
+		}
+		rows = append(rows, []string{option, num(totals[option]), fmt.Sprintf("%.2f", average)})
+	}
+
+	var report strings.Builder
+	report.WriteString(formatTable([]string{"Option", "Total score", "Average score"}, rows))
+
+	top := finalists(options, totals)
+	if len(top) < 2 {
+		return &Result{Winners: top, Report: report.String()}
+	}
+
+	first, second := top[0], top[1]
+	preferFirst, preferSecond := 0.0, 0.0
+	for _, ballot := range ballots {
+		switch {
+		case ballot.Scores[first] > ballot.Scores[second]:
+			preferFirst += ballot.Value()
+		case ballot.Scores[second] > ballot.Scores[first]:
+			preferSecond += ballot.Value()
+		}
+	}
+
+	report.WriteString(fmt.Sprintf("\n**Runoff:** %s — %s, %s — %s, no preference — %s\n",
+		first, num(preferFirst), second, num(preferSecond), num(weight-preferFirst-preferSecond)))
+
+	var winners []string
+	switch {
+	case preferFirst > preferSecond:
+		winners = []string{first}
+	case preferSecond > preferFirst:
+		winners = []string{second}
+	case totals[first] > totals[second]:
+		winners = []string{first}
+		report.WriteString("Runoff tied, the option with the higher total score wins.\n")
+	case totals[second] > totals[first]:
+		winners = []string{second}
+		report.WriteString("Runoff tied, the option with the higher total score wins.\n")
+	default:
+		winners = []string{first, second}
+	}
+
+	return &Result{
+		Winners: winners,
+		Report:  report.String(),
+	}
+}
+
+// finalists returns the two options with the highest total score.
+// Options tied on score keep their original order
+func finalists(options []string, totals map[string]float64) []string {
+	ordered := append([]string(nil), options...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return totals[ordered[i]] > totals[ordered[j]]
+	})
+	if len(ordered) > 2 {
+		ordered = ordered[:2]
+	}
+	return ordered
+}
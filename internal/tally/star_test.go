@@ -0,0 +1,66 @@
+package tally
+
+import (
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+func TestSTAR(t *testing.T) {
+	run(t, STAR{}, []countTest{
+		{
+			name:    "runoff overturns the score leader",
+			options: []string{"A", "B", "C"},
+			ballots: []model.Ballot{
+				scored(map[string]int{"A": 5, "B": 0}),
+				scored(map[string]int{"A": 0, "B": 1}),
+				scored(map[string]int{"A": 0, "B": 1}),
+			},
+			winners: []string{"B"},
+		},
+		{
+			name:    "only the two highest-scored options reach the runoff",
+			options: []string{"A", "B", "C"},
+			ballots: []model.Ballot{
+				scored(map[string]int{"A": 5, "B": 4, "C": 0}),
+				scored(map[string]int{"A": 4, "B": 5, "C": 0}),
+				scored(map[string]int{"A": 5, "B": 3, "C": 4}),
+			},
+			winners: []string{"A"},
+		},
+		{
+			name:    "runoff tie goes to the higher total score",
+			options: []string{"A", "B"},
+			ballots: []model.Ballot{
+				scored(map[string]int{"A": 5, "B": 0}),
+				scored(map[string]int{"A": 0, "B": 1}),
+			},
+			winners: []string{"A"},
+		},
+		{
+			name:    "runoff and total score tied",
+			options: []string{"A", "B"},
+			ballots: []model.Ballot{
+				scored(map[string]int{"A": 3, "B": 2}),
+				scored(map[string]int{"A": 2, "B": 3}),
+			},
+			winners: []string{"A", "B"},
+		},
+		{
+			name:    "weights count in the runoff",
+			options: []string{"A", "B"},
+			ballots: []model.Ballot{
+				weighted(3, scored(map[string]int{"A": 1, "B": 0})),
+				scored(map[string]int{"A": 0, "B": 5}),
+				scored(map[string]int{"A": 0, "B": 5}),
+			},
+			winners: []string{"A"},
+		},
+		{
+			name:    "single option",
+			options: []string{"A"},
+			ballots: []model.Ballot{scored(map[string]int{"A": 2})},
+			winners: []string{"A"},
+		},
+	})
+}
@@ -0,0 +1,186 @@
+package tally
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// STV implements the single transferable vote with the Droop quota
+// and fractional (Gregory) surplus transfers
+type STV struct {
+	Seats int
+}
+
+// Name returns the name of the method
+func (STV) Name() string {
+	return "Single transferable vote"
+}
+
+// stvBallot is a ballot being transferred during the count
+type stvBallot struct {
+	choices []string
+	value   float64
+}
+
+// current returns the continuing option the ballot counts for
+func (b *stvBallot) current(continuing map[string]bool) (string, bool) {
+	for _, choice := range b.choices {
+		if continuing[choice] {
+			return choice, true
+		}
+	}
+	return "", false
+}
+
+// Count elects options reaching the Droop quota and transfers their surplus
+// at a reduced value. When nobody reaches the quota the lowest option is eliminated
+// and its ballots are transferred at their current value. Ties for elimination
+// are broken by the option order. Every ballot starts with the voter's weight
+// and the quota is computed from the total weight
+func (c STV) Count(options []string, ballots []model.Ballot) *Result {
+	seats := c.Seats
+	if seats < 1 {
+		seats = 1
+	}
+
+	pile := make([]*stvBallot, 0, len(ballots))
+	weight := 0.0
+	for _, ballot := range ballots {
+		pile = append(pile, &stvBallot{choices: ballot.Choices, value: ballot.Value()})
+		weight += ballot.Value()
+	}
+
+	quota := math.Floor(weight/float64(seats+1)) + 1
+
+	continuing := make(map[string]bool, len(options))
+	for _, option := range options {
+		continuing[option] = true
+	}
+
+	var elected []string
+	var rounds []map[string]float64
+	var notes []string
+
+	for len(elected) < seats {
+		remaining := remainingOptions(options, continuing)
+		if len(remaining) == 0 {
+			break
+		}
+
+		totals := make(map[string]float64, len(remaining))
+		for _, option := range remaining {
+			totals[option] = 0
+		}
+		exhausted := 0.0
+		for _, ballot := range pile {
+			if option, ok := ballot.current(continuing); ok {
+				totals[option] += ballot.value
+			} else {
+				exhausted += ballot.value
+			}
+		}
+		totals[exhaustedRow] = exhausted
+		rounds = append(rounds, totals)
+
+		if len(remaining) <= seats-len(elected) {
+			elected = append(elected, remaining...)
+			notes = append(notes, fmt.Sprintf("%s elected to fill the remaining seats", strings.Join(remaining, ", ")))
+			break
+		}
+
+		reached := make([]string, 0)
+		for _, option := range remaining {
+			if totals[option] >= quota {
+				reached = append(reached, option)
+			}
+		}
+
+		if len(reached) > 0 {
+			sort.SliceStable(reached, func(i, j int) bool {
+				return totals[reached[i]] > totals[reached[j]]
+			})
+
+			if len(reached) > seats-len(elected) {
+				reached = reached[:seats-len(elected)]
+			}
+
+			ratios := make(map[string]float64, len(reached))
+			var parts []string
+			for _, option := range reached {
+				surplus := totals[option] - quota
+				ratios[option] = surplus / totals[option]
+				parts = append(parts, fmt.Sprintf("%s elected, surplus %.2f transferred", option, surplus))
+			}
+
+			// the surpluses of all options elected in this round are reduced in one pass
+			// before they stop continuing, so no winner's surplus lands on another winner
+			for _, ballot := range pile {
+				if current, ok := ballot.current(continuing); ok {
+					if ratio, reachedQuota := ratios[current]; reachedQuota {
+						ballot.value *= ratio
+					}
+				}
+			}
+			for _, option := range reached {
+				continuing[option] = false
+				elected = append(elected, option)
+			}
+			notes = append(notes, strings.Join(parts, "; "))
+			continue
+		}
+
+		lowest := lowestValued(remaining, totals)
+		continuing[lowest] = false
+		notes = append(notes, fmt.Sprintf("%s eliminated, %.2f transferred", lowest, totals[lowest]))
+	}
+
+	header := []string{"Option"}
+	for i := range rounds {
+		header = append(header, fmt.Sprintf("Round %d", i+1))
+	}
+
+	rows := make([][]string, 0, len(options)+1)
+	for _, option := range append(append([]string(nil), options...), exhaustedRow) {
+		row := []string{option}
+		for _, totals := range rounds {
+			if value, ok := totals[option]; ok {
+				row = append(row, fmt.Sprintf("%.2f", value))
+			} else {
+				row = append(row, "–")
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	var report strings.Builder
+	report.WriteString(fmt.Sprintf("**Seats:** %d, **Droop quota:** %.0f\n\n", seats, quota))
+	report.WriteString(formatTable(header, rows))
+	report.WriteString("\n")
+	for i, note := range notes {
+		report.WriteString(fmt.Sprintf("- Round %d: %s\n", i+1, note))
+	}
+
+	return &Result{
+		Winners: elected,
+		Report:  report.String(),
+	}
+}
+
+// exhaustedRow labels ballots with no continuing options left in the transfer table
+const exhaustedRow = "_Exhausted_"
+
+// lowestValued returns the option with the lowest total.
+// Among tied options the one listed last is returned
+func lowestValued(options []string, totals map[string]float64) string {
+	lowest := options[0]
+	for _, option := range options[1:] {
+		if totals[option] <= totals[lowest] {
+			lowest = option
+		}
+	}
+	return lowest
+}
@@ -0,0 +1,99 @@
+package tally
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+func TestSTV(t *testing.T) {
+	tests := []struct {
+		name    string
+		seats   int
+		options []string
+		ballots []model.Ballot
+		winners []string
+		report  []string // lines the report must contain
+	}{
+		{
+			name:    "single seat defaults and transfers like IRV",
+			options: []string{"A", "B", "C"},
+			ballots: join(repeat(2, ranked("A")), repeat(2, ranked("B")), repeat(1, ranked("C", "B"))),
+			winners: []string{"B"},
+			report:  []string{"**Seats:** 1, **Droop quota:** 3", "Round 1: C eliminated, 1.00 transferred"},
+		},
+		{
+			name:    "surplus transfers at a fractional value",
+			seats:   2,
+			options: []string{"A", "B", "C"},
+			ballots: join(repeat(6, ranked("A", "B")), repeat(3, ranked("C"))),
+			winners: []string{"A", "C"},
+			report: []string{
+				"**Seats:** 2, **Droop quota:** 4",
+				"Round 1: A elected, surplus 2.00 transferred",
+				"| B | 0.00 | 2.00 | – |",
+				"Round 2: B eliminated, 2.00 transferred",
+			},
+		},
+		{
+			name:    "options reaching the quota together are elected by total",
+			seats:   2,
+			options: []string{"A", "B", "C"},
+			ballots: join(repeat(3, ranked("A")), repeat(4, ranked("B")), repeat(1, ranked("C"))),
+			winners: []string{"B", "A"},
+			report:  []string{"Round 1: B elected, surplus 1.00 transferred; A elected, surplus 0.00 transferred"},
+		},
+		{
+			name:    "surplus skips options elected in the same round",
+			seats:   3,
+			options: []string{"A", "B", "C", "D"},
+			ballots: join(repeat(7, ranked("A", "B", "D")), repeat(5, ranked("B")), repeat(3, ranked("C")), repeat(2, ranked("D"))),
+			winners: []string{"A", "B", "D"},
+			report: []string{
+				"**Seats:** 3, **Droop quota:** 5",
+				"Round 1: A elected, surplus 2.00 transferred; B elected, surplus 0.00 transferred",
+				"| D | 2.00 | 4.00 | 4.00 |",
+				"Round 2: C eliminated, 3.00 transferred",
+			},
+		},
+		{
+			name:    "quota is computed from the total weight",
+			seats:   2,
+			options: []string{"A", "B", "C"},
+			ballots: []model.Ballot{weighted(5, ranked("A", "C")), ranked("B"), ranked("B"), ranked("C")},
+			winners: []string{"A", "C"},
+			report:  []string{"**Droop quota:** 3", "Round 1: A elected, surplus 2.00 transferred"},
+		},
+		{
+			name:    "elimination tie removes the option listed last",
+			options: []string{"A", "B", "C"},
+			ballots: []model.Ballot{ranked("A"), ranked("B"), ranked("C", "A")},
+			winners: []string{"A"},
+			report:  []string{"Round 1: C eliminated"},
+		},
+		{
+			name:    "remaining options fill the remaining seats",
+			seats:   2,
+			options: []string{"A", "B"},
+			ballots: []model.Ballot{ranked("A")},
+			winners: []string{"A", "B"},
+			report:  []string{"A, B elected to fill the remaining seats"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := STV{Seats: tt.seats}.Count(tt.options, tt.ballots)
+			if !reflect.DeepEqual(result.Winners, tt.winners) {
+				t.Errorf("winners = %q, want %q\n%s", result.Winners, tt.winners, result.Report)
+			}
+			for _, line := range tt.report {
+				if !strings.Contains(result.Report, line) {
+					t.Errorf("report doesn't contain %q\n%s", line, result.Report)
+				}
+			}
+		})
+	}
+}
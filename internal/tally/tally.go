@@ -0,0 +1,97 @@
+package tally
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// Counter defines a method of counting ballots
+type Counter interface {
+	// Name returns a human-readable name of the method
+	Name() string
+	// Count counts ballots and returns the outcome
+	Count(options []string, ballots []model.Ballot) *Result
+}
+
+// Result contains the outcome of a count
+type Result struct {
+	Winners []string // more than one winner means a tie
+	Report  string   // method-specific breakdown in markdown
+}
+
+var (
+	mu       sync.RWMutex
+	counters = map[string]Counter{
+		model.MethodIRV:       IRV{},
+		model.MethodBorda:     Borda{},
+		model.MethodSchulze:   Schulze{},
+		model.MethodSTAR:      STAR{},
+		model.MethodQuadratic: Quadratic{},
+	}
+)
+
+// Register registers a counter for the given method name
+func Register(method string, counter Counter) {
+	mu.Lock()
+	defer mu.Unlock()
+	counters[method] = counter
+}
+
+// Get returns the counter registered for the given method name
+func Get(method string) (Counter, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	counter, ok := counters[method]
+	return counter, ok
+}
+
+// rank returns the position of every ranked option on the ballot
+func rank(ballot model.Ballot) map[string]int {
+	positions := make(map[string]int, len(ballot.Choices))
+	for i, choice := range ballot.Choices {
+		if _, exists := positions[choice]; !exists {
+			positions[choice] = i
+		}
+	}
+	return positions
+}
+
+// topScored returns the options with the highest score
+func topScored(options []string, scores map[string]float64) []string {
+	var winners []string
+	best := 0.0
+	for _, option := range options {
+		score := scores[option]
+		switch {
+		case len(winners) == 0 || score > best:
+			winners = []string{option}
+			best = score
+		case score == best:
+			winners = append(winners, option)
+		}
+	}
+	return winners
+}
+
+// formatTable renders a markdown table
+func formatTable(header []string, rows [][]string) string {
+	var sb strings.Builder
+	sb.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	sb.WriteString("|" + strings.Repeat(" --- |", len(header)) + "\n")
+	for _, row := range rows {
+		sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+	}
+	return sb.String()
+}
+
+// num formats weighted counts in tables, whole numbers are printed without decimals
+func num(value float64) string {
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%.0f", value)
+	}
+	return fmt.Sprintf("%.2f", value)
+}
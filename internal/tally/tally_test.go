@@ -0,0 +1,91 @@
+package tally
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hard-gainer/voting-bot/internal/model"
+)
+
+// countTest is a count of the ballots with its expected winners
+type countTest struct {
+	name    string
+	options []string
+	ballots []model.Ballot
+	winners []string
+}
+
+// run counts the ballots of every test with the counter and checks the winners
+func run(t *testing.T, counter Counter, tests []countTest) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := counter.Count(tt.options, tt.ballots)
+			if !reflect.DeepEqual(result.Winners, tt.winners) {
+				t.Errorf("winners = %q, want %q\n%s", result.Winners, tt.winners, result.Report)
+			}
+		})
+	}
+}
+
+// ranked returns a ballot ranking the choices in order of preference
+func ranked(choices ...string) model.Ballot {
+	return model.Ballot{Choices: choices}
+}
+
+// scored returns a ballot with the given scores
+func scored(scores map[string]int) model.Ballot {
+	return model.Ballot{Scores: scores}
+}
+
+// weighted returns the ballot with the voter's weight
+func weighted(weight float64, ballot model.Ballot) model.Ballot {
+	ballot.Weight = weight
+	return ballot
+}
+
+// repeat returns n copies of the ballot
+func repeat(n int, ballot model.Ballot) []model.Ballot {
+	ballots := make([]model.Ballot, n)
+	for i := range ballots {
+		ballots[i] = ballot
+	}
+	return ballots
+}
+
+// join concatenates groups of ballots
+func join(groups ...[]model.Ballot) []model.Ballot {
+	var ballots []model.Ballot
+	for _, group := range groups {
+		ballots = append(ballots, group...)
+	}
+	return ballots
+}
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		method string
+		name   string
+		ok     bool
+	}{
+		{model.MethodIRV, "Instant-runoff", true},
+		{model.MethodBorda, "Borda count", true},
+		{model.MethodSchulze, "Schulze method", true},
+		{model.MethodSTAR, "STAR voting", true},
+		{model.MethodQuadratic, "Quadratic voting", true},
+		{"plurality", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			counter, ok := Get(tt.method)
+			if ok != tt.ok {
+				t.Fatalf("Get(%q) ok = %v, want %v", tt.method, ok, tt.ok)
+			}
+			if ok && counter.Name() != tt.name {
+				t.Errorf("Get(%q).Name() = %q, want %q", tt.method, counter.Name(), tt.name)
+			}
+		})
+	}
+}